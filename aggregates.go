@@ -0,0 +1,202 @@
+package conditions
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// wildcardStep in a path like "items.*.total" stands for every element of a list or value of a map.
+const wildcardStep = "*"
+
+// getWildcardValues resolves the rest of a path for every element of a list or value of a map, and
+// returns the results as a list. Maps are visited in key order, and missing values are nil.
+func (c *Conditions) getWildcardValues(instance any, rest []string) []any {
+	val := reflect.ValueOf(instance)
+	if val.Kind() == reflect.Pointer {
+		val = val.Elem()
+	}
+
+	var items []any
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			items = append(items, val.Index(i).Interface())
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(val) {
+			items = append(items, val.MapIndex(key).Interface())
+		}
+	default:
+		return nil
+	}
+
+	path := strings.Join(rest, ".")
+	nested := contains(rest, wildcardStep)
+	values := make([]any, 0, len(items))
+	for _, item := range items {
+		if path == "" {
+			values = append(values, item)
+			continue
+		}
+		value := c.getValueByChain(path, item)
+		if list, ok := value.([]any); ok && nested {
+			values = append(values, list...)
+		} else {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// countFilter is the key of the $count operand holding the sub-condition elements must satisfy.
+const countFilter = "filter"
+
+// checkAggregateOperator handles $size, $count and the numeric aggregates $sum, $avg, $min and $max.
+// The derived number is checked like $len: against a number, or a map of operators such as {"$gt": 500}.
+func (c *Conditions) checkAggregateOperator(operator CommonOperatorsEnum, key string, fact any, conditionValue any, instance any) (bool, error) {
+	if operator == SIZE {
+		val := reflect.ValueOf(fact)
+		switch val.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return c.checkDerivedNumber(SIZE, val.Len(), conditionValue)
+		default:
+			return false, fmt.Errorf("expected a slice or map for instance value under key %s, got %T", key, fact)
+		}
+	}
+
+	elements, ok := listElements(fact)
+	if !ok {
+		return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
+	}
+
+	if operator == COUNT {
+		return c.checkCount(key, elements, conditionValue, instance)
+	}
+
+	var values []any
+	var numbers []number
+	for _, element := range elements {
+		if element == nil {
+			continue // Missing values are left out, as in SQL
+		}
+		n, ok := toNumber(element)
+		if !ok || !isNumber(element) {
+			return false, fmt.Errorf("expected numeric elements for %s operator, got %T", operator, element)
+		}
+		values = append(values, element)
+		numbers = append(numbers, n)
+	}
+	if len(numbers) == 0 && operator != SUM {
+		return false, fmt.Errorf("cannot compute %s of an empty list", operator)
+	}
+
+	var derived any
+	switch operator {
+	case SUM:
+		derived = sumNumbers(numbers)
+	case AVG:
+		derived = divideNumber(sumNumbers(numbers), len(numbers))
+	case MIN, MAX:
+		derived = extremeNumber(operator, values, numbers)
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+	return c.checkDerivedNumber(operator, derived, conditionValue)
+}
+
+// checkCount counts the elements, or with a "filter" sub-condition the elements that satisfy it.
+// An element the filter cannot be evaluated for is not counted, and the first such error is returned.
+func (c *Conditions) checkCount(key string, elements []any, conditionValue any, instance any) (bool, error) {
+	spec, ok := conditionValue.(map[string]any)
+	if !ok {
+		return c.checkDerivedNumber(COUNT, len(elements), conditionValue)
+	}
+
+	filter, hasFilter := spec[countFilter]
+	operators := make(map[string]any, len(spec))
+	for name, value := range spec {
+		if name != countFilter {
+			operators[name] = value
+		}
+	}
+	if len(operators) == 0 {
+		return false, fmt.Errorf("expected operators for $count operator, got %v", conditionValue)
+	}
+
+	count := len(elements)
+	var reported error // The first error of an element that does not count
+	if hasFilter {
+		condition, ok := filter.(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("expected sub-condition map for $count filter, got %T", filter)
+		}
+		count = 0
+		for i, element := range elements {
+			matched, err := c.matchElement(fmt.Sprintf("%s[%d]", key, i), element, condition, instance)
+			if reported == nil {
+				reported = err
+			}
+			if matched {
+				count++
+			}
+		}
+	}
+	matched, err := c.checkDerivedNumber(COUNT, count, operators)
+	if err != nil {
+		return false, err
+	}
+	return matched, reported
+}
+
+// sumNumbers adds numbers exactly. The sum is a *big.Rat, or a float64 when any of the numbers is a
+// float, so that float sums keep float semantics and NaN and infinities propagate.
+func sumNumbers(numbers []number) any {
+	sum := new(big.Rat)
+	isFloat := false
+	for _, n := range numbers {
+		if n.rat == nil {
+			return floatSum(numbers)
+		}
+		isFloat = isFloat || n.prec > 0
+		sum.Add(sum, n.rat)
+	}
+	if isFloat {
+		f, _ := sum.Float64()
+		return f
+	}
+	return sum
+}
+
+func floatSum(numbers []number) float64 {
+	var sum float64
+	for _, n := range numbers {
+		sum += n.toFloat64()
+	}
+	return sum
+}
+
+// divideNumber divides a sum by a count, keeping exact sums exact.
+func divideNumber(sum any, count int) any {
+	if r, ok := sum.(*big.Rat); ok {
+		return new(big.Rat).Quo(r, big.NewRat(int64(count), 1))
+	}
+	return sum.(float64) / float64(count)
+}
+
+// extremeNumber returns the value with the smallest or largest number. NaN makes the result NaN.
+func extremeNumber(operator CommonOperatorsEnum, values []any, numbers []number) any {
+	best := 0
+	for i, n := range numbers {
+		if n.isNaN() {
+			return math.NaN()
+		}
+		result := n.compare(numbers[best])
+		if operator == MIN && result < 0 || operator == MAX && result > 0 {
+			best = i
+		}
+	}
+	return values[best]
+}
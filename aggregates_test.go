@@ -0,0 +1,167 @@
+package conditions
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAggregateOperators(t *testing.T) {
+	cond := NewConditions()
+	cart := map[string]any{
+		"items": []any{
+			map[string]any{"name": "Cable", "total": 12.5, "qty": 1, "category": "accessories"},
+			map[string]any{"name": "Monitor", "total": 240, "qty": 2, "category": "electronics"},
+			map[string]any{"name": "Laptop", "total": json.Number("1299.99"), "qty": 1, "category": "electronics"},
+		},
+		"readings": []float64{71.5, 88.2, 79.9},
+		"counts":   []int{1, 2, 4},
+		"sensors": map[string]any{
+			"north": map[string]any{"temp": 81.0},
+			"south": map[string]any{"temp": 92.5},
+		},
+		"empty": []int{},
+	}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      bool
+	}{
+		{
+			name:      "Test $size operator with nested operator",
+			condition: map[string]any{"{{items}}": map[string]any{"$size": map[string]any{"$gte": 3}}},
+			want:      true,
+		},
+		{
+			name:      "Test $size operator with exact number",
+			condition: map[string]any{"{{sensors}}": map[string]any{"$size": 2}},
+			want:      true,
+		},
+		{
+			name:      "Test $sum operator over wildcard path",
+			condition: map[string]any{"{{items.*.total}}": map[string]any{"$sum": map[string]any{"$gt": 500}}},
+			want:      true,
+		},
+		{
+			name:      "Test $sum operator with exact match",
+			condition: map[string]any{"{{items.*.qty}}": map[string]any{"$sum": 4}},
+			want:      true,
+		},
+		{
+			name:      "Test $sum operator with empty list",
+			condition: map[string]any{"{{empty}}": map[string]any{"$sum": 0}},
+			want:      true,
+		},
+		{
+			name:      "Test $max operator false",
+			condition: map[string]any{"{{readings}}": map[string]any{"$max": map[string]any{"$lt": 88}}},
+			want:      false,
+		},
+		{
+			name:      "Test $max operator over map wildcard",
+			condition: map[string]any{"{{sensors.*.temp}}": map[string]any{"$max": map[string]any{"$gte": 90}}},
+			want:      true,
+		},
+		{
+			name:      "Test $min operator with exact match",
+			condition: map[string]any{"{{readings}}": map[string]any{"$min": 71.5}},
+			want:      true,
+		},
+		{
+			name:      "Test $avg operator exact",
+			condition: map[string]any{"{{counts}}": map[string]any{"$avg": map[string]any{"$gt": 2.33, "$lt": 2.34}}},
+			want:      true,
+		},
+		{
+			name:      "Test $avg operator with empty list",
+			condition: map[string]any{"{{empty}}": map[string]any{"$avg": map[string]any{"$gte": 0}}},
+			want:      false,
+		},
+		{
+			name:      "Test $sum operator with non-numeric elements",
+			condition: map[string]any{"{{items.*.name}}": map[string]any{"$sum": map[string]any{"$gt": 0}}},
+			want:      false,
+		},
+		{
+			name:      "Test $count operator without filter",
+			condition: map[string]any{"{{items}}": map[string]any{"$count": 3}},
+			want:      true,
+		},
+		{
+			name: "Test $count operator with filter",
+			condition: map[string]any{"{{items}}": map[string]any{"$count": map[string]any{
+				"filter": map[string]any{"{{category}}": map[string]any{"$eq": "electronics"}},
+				"$gte":   2,
+			}}},
+			want: true,
+		},
+		{
+			name: "Test $count operator with operator filter",
+			condition: map[string]any{"{{readings}}": map[string]any{"$count": map[string]any{
+				"filter": map[string]any{"$gt": 75},
+				"$eq":    2,
+			}}},
+			want: true,
+		},
+		{
+			name: "Test $count operator with filter and no operators",
+			condition: map[string]any{"{{items}}": map[string]any{"$count": map[string]any{
+				"filter": map[string]any{"{{qty}}": map[string]any{"$gt": 1}},
+			}}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(cart, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountSkipsElementsThatFail(t *testing.T) {
+	cond := NewConditions()
+	// The second item has no price, so it is not counted.
+	instance := map[string]any{"items": []any{
+		map[string]any{"name": "Laptop", "price": 1200},
+		map[string]any{"name": "Gift card"},
+		map[string]any{"name": "Monitor", "price": 240},
+	}}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      bool
+	}{
+		{
+			name: "Test $count counts the elements that match",
+			condition: map[string]any{"{{items}}": map[string]any{"$count": map[string]any{
+				"filter": map[string]any{"{{price}}": map[string]any{"$gt": 100}},
+				"$eq":    2,
+			}}},
+			want: true,
+		},
+		{
+			name: "Test $count does not count an element that fails",
+			condition: map[string]any{"{{items}}": map[string]any{"$count": map[string]any{
+				"filter": map[string]any{"{{price}}": map[string]any{"$gt": 100}},
+				"$gt":    2,
+			}}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+			got, err := cond.Evaluate(instance, tt.condition)
+			if got != tt.want || err == nil {
+				t.Errorf("Evaluate() for %s = %v, %v, want %v and the error of the gift card", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package conditions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// maxBitPosition bounds the bit positions accepted in a mask, so a typo cannot allocate a huge integer.
+const maxBitPosition = 1 << 16
+
+// toBigInt converts integers of any width, *big.Int, json.Number and integral floats to a big.Int without
+// losing precision.
+func toBigInt(value any) (*big.Int, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, false
+		}
+		return new(big.Int).Set(v), true
+	case big.Int:
+		return new(big.Int).Set(&v), true
+	case json.Number:
+		return new(big.Int).SetString(string(v), 10)
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) || f != math.Trunc(f) {
+			return nil, false
+		}
+		n, _ := big.NewFloat(f).Int(nil)
+		return n, true
+	default:
+		return nil, false
+	}
+}
+
+// toBitMask reads a bitmask operand: an integer mask, or a list of bit positions where 0 is the least
+// significant bit.
+func toBitMask(operand any) (*big.Int, error) {
+	if mask, ok := toBigInt(operand); ok {
+		if mask.Sign() < 0 {
+			return nil, fmt.Errorf("expected non-negative bitmask, got %v", operand)
+		}
+		return mask, nil
+	}
+	positions := reflect.ValueOf(operand)
+	if positions.Kind() != reflect.Slice && positions.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected integer bitmask or list of bit positions, got %T", operand)
+	}
+	mask := new(big.Int)
+	for i := 0; i < positions.Len(); i++ {
+		position, ok := toBigInt(positions.Index(i).Interface())
+		if !ok || !position.IsInt64() || position.Int64() < 0 || position.Int64() > maxBitPosition {
+			return nil, fmt.Errorf("invalid bit position %v", positions.Index(i).Interface())
+		}
+		mask.SetBit(mask, int(position.Int64()), 1)
+	}
+	return mask, nil
+}
+
+// checkBitsOperator handles $power and the $bits operators. Negative facts use two's complement, so -1
+// has every bit set.
+func (c *Conditions) checkBitsOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	num, ok := toBigInt(fact)
+	if !ok {
+		return false, fmt.Errorf("expected integer type for instance value, got %T", fact)
+	}
+	mask, err := toBitMask(conditionValue)
+	if err != nil {
+		return false, err
+	}
+	masked := new(big.Int).And(num, mask)
+
+	switch operator {
+	case POWER, BITSANYSET:
+		return masked.Sign() != 0, nil
+	case BITSALLSET:
+		return masked.Cmp(mask) == 0, nil
+	case BITSALLCLEAR:
+		return masked.Sign() == 0, nil
+	case BITSANYCLEAR:
+		return masked.Cmp(mask) != 0, nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
@@ -0,0 +1,93 @@
+package conditions
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBitsOperators(t *testing.T) {
+	cond := NewConditions()
+	huge, _ := new(big.Int).SetString("340282366920938463463374607431768211456", 10) // 2^128
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $power operator above 2^53",
+			condition: map[string]any{"{{perms}}": map[string]any{"$power": uint64(1)}},
+			instance:  map[string]any{"perms": uint64(1<<63 | 1)},
+			want:      true,
+		},
+		{
+			name:      "Test $power operator with high bit of uint64",
+			condition: map[string]any{"{{perms}}": map[string]any{"$power": uint64(1 << 63)}},
+			instance:  map[string]any{"perms": uint64(1 << 63)},
+			want:      true,
+		},
+		{
+			name:      "Test $power operator with big.Int",
+			condition: map[string]any{"{{perms}}": map[string]any{"$power": huge}},
+			instance:  map[string]any{"perms": new(big.Int).Add(huge, big.NewInt(1))},
+			want:      true,
+		},
+		{
+			name:      "Test $bitsAllSet operator with mask",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAllSet": 0b1010}},
+			instance:  map[string]any{"flags": 0b1110},
+			want:      true,
+		},
+		{
+			name:      "Test $bitsAllSet operator with positions",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAllSet": []int{1, 5}}},
+			instance:  map[string]any{"flags": 0b0010},
+			want:      false,
+		},
+		{
+			name:      "Test $bitsAnySet operator with positions",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAnySet": []any{0, 62}}},
+			instance:  map[string]any{"flags": int64(1 << 62)},
+			want:      true,
+		},
+		{
+			name:      "Test $bitsAllClear operator true",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAllClear": []int{0, 1}}},
+			instance:  map[string]any{"flags": 0b1100},
+			want:      true,
+		},
+		{
+			name:      "Test $bitsAnyClear operator false",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAnyClear": 0b11}},
+			instance:  map[string]any{"flags": 0b111},
+			want:      false,
+		},
+		{
+			name:      "Test $bitsAllSet operator with negative fact",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAllSet": []int{0, 100}}},
+			instance:  map[string]any{"flags": -1},
+			want:      true,
+		},
+		{
+			name:      "Test $bitsAnySet operator with fractional fact",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAnySet": 1}},
+			instance:  map[string]any{"flags": 1.5},
+			want:      false,
+		},
+		{
+			name:      "Test $bitsAnySet operator with negative position",
+			condition: map[string]any{"{{flags}}": map[string]any{"$bitsAnySet": []int{-1}}},
+			instance:  map[string]any{"flags": 1},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package conditions
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CoercionPolicy controls how values of different types are converted before they are compared.
+type CoercionPolicy int
+
+const (
+	// CoercionNone compares values as they are. This is the default.
+	CoercionNone CoercionPolicy = iota
+	// CoercionLenient converts strings to the type of the other side: numeric strings to exact numbers,
+	// "true" and "false" to bools and RFC 3339 strings to times. Two decimal strings are compared as
+	// exact numbers, and numbers of different Go types are equal when their values are.
+	CoercionLenient
+	// CoercionJavaScript follows JavaScript's loose equality and relational comparison: on top of the
+	// lenient conversions, bools compare as 0 and 1, the empty string as 0, and truthiness follows
+	// JavaScript, so "false" and "0" are truthy and 0, "" and null are falsy.
+	CoercionJavaScript
+)
+
+// WithCoercion sets the coercion policy applied by comparisons, equality and truthiness operators.
+func WithCoercion(policy CoercionPolicy) Option {
+	return func(c *Conditions) {
+		c.coercion = policy
+	}
+}
+
+// coerceNumber converts json.Number values to int64 or float64 and leaves other values unchanged.
+func coerceNumber(value any) any {
+	if n, ok := value.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		if f, err := n.Float64(); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+func isNumber(value any) bool {
+	_, ok := toNumber(value)
+	return ok && reflect.TypeOf(value) != reflect.TypeOf(time.Duration(0))
+}
+
+// coercePair converts two values towards a common type according to the coercion policy.
+// Values that cannot be converted are returned unchanged, so the comparison reports the mismatch.
+func (c *Conditions) coercePair(a, b any) (any, any) {
+	if c.coercion == CoercionNone {
+		return a, b
+	}
+
+	if c.coercion == CoercionJavaScript {
+		a, b = javaScriptPrimitive(a, b), javaScriptPrimitive(b, a)
+	} else if ra, ok := parseDecimalString(a); ok {
+		// Two decimal strings, like monetary amounts, are compared by their exact values
+		if rb, ok := parseDecimalString(b); ok {
+			return ra, rb
+		}
+	}
+	return c.coerceTowards(a, b), c.coerceTowards(b, a)
+}
+
+// decimalString matches plain decimal numbers like "42", "-9.50" or "1.5e3".
+var decimalString = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// parseDecimalString converts a string in plain decimal notation to an exact number.
+func parseDecimalString(value any) (*big.Rat, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+	str = strings.TrimSpace(str)
+	if !decimalString.MatchString(str) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(str)
+}
+
+// javaScriptPrimitive converts a bool to 0 or 1 when the other side is not a bool, as JavaScript does.
+func javaScriptPrimitive(value, other any) any {
+	if flag, ok := value.(bool); ok {
+		if _, otherIsBool := other.(bool); !otherIsBool {
+			if flag {
+				return 1
+			}
+			return 0
+		}
+	}
+	return value
+}
+
+// coerceTowards converts a string value to the type of the other value.
+func (c *Conditions) coerceTowards(value, other any) any {
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+	trimmed := strings.TrimSpace(str)
+
+	switch {
+	case isNumber(other):
+		if c.coercion == CoercionJavaScript && trimmed == "" {
+			return 0
+		}
+		// Decimal strings are kept exact, so "0.30" equals a Decimal of 0.3
+		if r, ok := new(big.Rat).SetString(trimmed); ok {
+			return r
+		}
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return f
+		}
+	case reflect.TypeOf(other) == reflect.TypeOf(true):
+		switch strings.ToLower(trimmed) {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	case reflect.TypeOf(other) == reflect.TypeOf(time.Time{}):
+		if t, err := time.Parse(time.RFC3339Nano, trimmed); err == nil {
+			return t
+		}
+	}
+	return value
+}
+
+// valuesEqual compares two values for $eq, $ne and bare equality conditions.
+// Without coercion it is reflect.DeepEqual, except that big numbers, json.Number and Decimal values are
+// compared by value; with coercion all numbers and times are compared by value.
+func (c *Conditions) valuesEqual(a, b any) bool {
+	if c.coercion == CoercionNone {
+		if isArbitraryPrecision(a) || isArbitraryPrecision(b) {
+			result, ok := compareNumbers(a, b)
+			return ok && result == 0
+		}
+		return reflect.DeepEqual(a, b)
+	}
+
+	a, b = c.coercePair(a, b)
+	if isNumber(a) && isNumber(b) {
+		result, ok := compareNumbers(a, b)
+		return ok && result == 0
+	}
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// coerceTruth converts a fact for $truly and $falsy. The lenient policy reads "true", "false",
+// 1 and 0 (also as strings) as bools, and the JavaScript policy applies JavaScript truthiness.
+func (c *Conditions) coerceTruth(fact any) any {
+	fact = coerceNumber(fact)
+
+	switch c.coercion {
+	case CoercionLenient:
+		switch v := fact.(type) {
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true", "1":
+				return true
+			case "false", "0":
+				return false
+			}
+		default:
+			if f, ok := toFloat64(v); ok && isNumber(v) && (f == 0 || f == 1) {
+				return f == 1
+			}
+		}
+		return fact
+	case CoercionJavaScript:
+		return javaScriptTruthy(fact)
+	default:
+		return fact
+	}
+}
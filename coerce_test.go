@@ -0,0 +1,136 @@
+package conditions
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCoercionPolicies(t *testing.T) {
+	none := NewConditions()
+	lenient := NewConditions(WithCoercion(CoercionLenient))
+	javaScript := NewConditions(WithCoercion(CoercionJavaScript))
+	moment := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		cond      *Conditions
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $gt with numeric string without coercion",
+			cond:      none,
+			condition: map[string]any{"{{age}}": map[string]any{"$gt": 18}},
+			instance:  map[string]any{"age": "42"},
+			want:      false,
+		},
+		{
+			name:      "Test $gt with numeric string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{age}}": map[string]any{"$gt": 18}},
+			instance:  map[string]any{"age": "42"},
+			want:      true,
+		},
+		{
+			name:      "Test $between with json.Number and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{price}}": map[string]any{"$between": []int{10, 20}}},
+			instance:  map[string]any{"price": json.Number("12.5")},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with numbers of different types and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{count}}": map[string]any{"$eq": 3}},
+			instance:  map[string]any{"count": float64(3)},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with bool string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{active}}": map[string]any{"$eq": true}},
+			instance:  map[string]any{"active": "TRUE"},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with RFC 3339 string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{at}}": map[string]any{"$eq": moment}},
+			instance:  map[string]any{"at": "2024-05-01T14:00:00+02:00"},
+			want:      true,
+		},
+		{
+			name:      "Test bare equality with numeric string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{zip}}": 10115},
+			instance:  map[string]any{"zip": "10115"},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with non-numeric string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{age}}": map[string]any{"$eq": 42}},
+			instance:  map[string]any{"age": "forty-two"},
+			want:      false,
+		},
+		{
+			name:      "Test $truly with string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"$truly": "flag"},
+			instance:  map[string]any{"flag": "true"},
+			want:      true,
+		},
+		{
+			name:      "Test $falsy with zero and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"$falsy": "flag"},
+			instance:  map[string]any{"flag": 0},
+			want:      true,
+		},
+		{
+			name:      "Test $truly with string without coercion",
+			cond:      none,
+			condition: map[string]any{"$truly": "flag"},
+			instance:  map[string]any{"flag": "true"},
+			want:      false,
+		},
+		{
+			name:      "Test $eq with bool and number and JavaScript coercion",
+			cond:      javaScript,
+			condition: map[string]any{"{{enabled}}": map[string]any{"$eq": 1}},
+			instance:  map[string]any{"enabled": true},
+			want:      true,
+		},
+		{
+			name:      "Test $lt with empty string and JavaScript coercion",
+			cond:      javaScript,
+			condition: map[string]any{"{{value}}": map[string]any{"$lt": 1}},
+			instance:  map[string]any{"value": ""},
+			want:      true,
+		},
+		{
+			name:      "Test $truly with string false and JavaScript coercion",
+			cond:      javaScript,
+			condition: map[string]any{"$truly": "flag"},
+			instance:  map[string]any{"flag": "false"},
+			want:      true,
+		},
+		{
+			name:      "Test $falsy with empty string and JavaScript coercion",
+			cond:      javaScript,
+			condition: map[string]any{"$falsy": "flag"},
+			instance:  map[string]any{"flag": ""},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
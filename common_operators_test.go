@@ -2,6 +2,7 @@ package conditions
 
 import (
 	"testing"
+	"time"
 )
 
 func TestCheckCommonOperators(t *testing.T) {
@@ -89,6 +90,22 @@ func TestCheckCommonOperators(t *testing.T) {
 			instance: map[string]any{"tag": "missing"},
 			want:     true,
 		},
+		{
+			name: "Test $in operator with non-string fact",
+			condition: map[string]any{
+				"{{age}}": map[string]any{"$in": []string{"30"}},
+			},
+			instance: map[string]any{"age": 30},
+			want:     false,
+		},
+		{
+			name: "Test $ni operator with non-string fact",
+			condition: map[string]any{
+				"{{age}}": map[string]any{"$ni": []string{"30"}},
+			},
+			instance: map[string]any{"age": 30},
+			want:     false,
+		},
 		{
 			name: "Test $re operator true",
 			condition: map[string]any{
@@ -169,6 +186,76 @@ func TestCheckCommonOperators(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Test $between operator with strings",
+			condition: map[string]any{
+				"{{sku}}": map[string]any{"$between": []string{"A000", "A999"}},
+			},
+			instance: map[string]any{
+				"sku": "A512",
+			},
+			want: true,
+		},
+		{
+			name: "Test $gt operator with mismatched types",
+			condition: map[string]any{
+				"{{age}}": map[string]any{"$gt": "18"},
+			},
+			instance: map[string]any{
+				"age": 30,
+			},
+			want: false,
+		},
+		{
+			name: "Test $gt operator with duration string",
+			condition: map[string]any{
+				"{{job.runtime}}": map[string]any{"$gt": "15m"},
+			},
+			instance: map[string]any{
+				"job": map[string]any{"runtime": 20 * time.Minute},
+			},
+			want: true,
+		},
+		{
+			name: "Test $lte operator with byte size",
+			condition: map[string]any{
+				"{{upload.size}}": map[string]any{"$lte": "25MiB"},
+			},
+			instance: map[string]any{
+				"upload": map[string]any{"size": 30_000_000},
+			},
+			want: false,
+		},
+		{
+			name: "Test $lt operator with duration strings",
+			condition: map[string]any{
+				"{{job.timeout}}": map[string]any{"$lt": "15m"},
+			},
+			instance: map[string]any{
+				"job": map[string]any{"timeout": "9m"},
+			},
+			want: true,
+		},
+		{
+			name: "Test $gt operator with byte size strings",
+			condition: map[string]any{
+				"{{upload.limit}}": map[string]any{"$gt": "9MB"},
+			},
+			instance: map[string]any{
+				"upload": map[string]any{"limit": "10MB"},
+			},
+			want: true,
+		},
+		{
+			name: "Test $between operator with durations",
+			condition: map[string]any{
+				"{{latency}}": map[string]any{"$between": []string{"100ms", "1s"}},
+			},
+			instance: map[string]any{
+				"latency": 250 * time.Millisecond,
+			},
+			want: true,
+		},
 		{
 			name: "Test $some operator true",
 			condition: map[string]any{
@@ -66,3 +66,105 @@ func TestComplexConditionsWithBetweenAndSome(t *testing.T) {
 		t.Errorf("Check() = %v, want %v", got, want)
 	}
 }
+
+func TestEvaluateReturnsErrors(t *testing.T) {
+	cond := NewConditions()
+	instance := map[string]any{"age": 30, "name": "John"}
+
+	got, err := cond.Evaluate(instance, map[string]any{
+		"$and": []map[string]any{
+			{"{{age}}": map[string]any{"$gte": 18}},
+			{"{{name}}": map[string]any{"$eq": "John"}},
+		},
+	})
+	if err != nil || !got {
+		t.Errorf("Evaluate() = %v, %v, want true, nil", got, err)
+	}
+
+	got, err = cond.Evaluate(instance, map[string]any{"{{age}}": map[string]any{"$sw": "3"}})
+	if err == nil || got {
+		t.Errorf("Evaluate() = %v, %v, want false and an error", got, err)
+	}
+
+	got, err = cond.Evaluate(instance, map[string]any{"$or": "invalid"})
+	if err == nil || got {
+		t.Errorf("Evaluate() = %v, %v, want false and an error", got, err)
+	}
+}
+
+func TestConditionMapKeysAreCombined(t *testing.T) {
+	cond := NewConditions()
+	instance := map[string]any{"age": 30, "name": "John"}
+
+	condition := map[string]any{
+		"{{age}}":  map[string]any{"$gte": 18, "$lt": 25},
+		"{{name}}": map[string]any{"$eq": "John"},
+	}
+
+	// Every key and every operator must match, whatever the map iteration order
+	for i := 0; i < 20; i++ {
+		if cond.Check(instance, condition) {
+			t.Fatalf("Check() = true, want false")
+		}
+	}
+}
+
+func TestEvaluateAgreesWithCheck(t *testing.T) {
+	cond := NewConditions()
+	instance := map[string]any{"name": "x", "age": 30}
+	failingSchema := map[string]any{"{{name}}": map[string]any{"$schema": map[string]any{"type": "string", "minLength": 2}}}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name: "Test $and with a negated schema and a mismatch",
+			condition: map[string]any{"$and": []map[string]any{
+				{"$not": []map[string]any{failingSchema}},
+				{"{{age}}": map[string]any{"$eq": 31}},
+			}},
+			want: false,
+		},
+		{
+			name: "Test $or with an erroring and a matching branch",
+			condition: map[string]any{"$or": []map[string]any{
+				{"{{age}}": map[string]any{"$sw": "3"}},
+				{"{{age}}": map[string]any{"$gte": 18}},
+			}},
+			want: true,
+		},
+		{
+			name: "Test $or without a matching branch",
+			condition: map[string]any{"$or": []map[string]any{
+				{"{{age}}": map[string]any{"$sw": "3"}},
+				{"{{age}}": map[string]any{"$lt": 18}},
+			}},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Test $and with an erroring key",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$eq": "x"},
+				"{{age}}":  map[string]any{"$sw": "3"},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+			got, err := cond.Evaluate(instance, tt.condition)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("Evaluate() for %s = %v, %v, want %v and error %v", tt.name, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
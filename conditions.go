@@ -4,40 +4,99 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 func (c *Conditions) Check(instance any, condition any) bool {
+	return c.check(instance, condition, &evaluation{})
+}
+
+// Evaluate checks the condition like Check and returns the same result, along with the first error met
+// in the part of the condition that decided it, like an operand of the wrong type or a failed $schema
+// validation. Errors under $not and in $or branches that were not needed are left out.
+func (c *Conditions) Evaluate(instance any, condition any) (bool, error) {
+	state := &evaluation{}
+	result := c.check(instance, condition, state)
+	return result, state.err
+}
+
+// evaluation carries the state of a single Check or Evaluate call, or of a part of the condition whose
+// errors are only kept when it decides the result.
+type evaluation struct {
+	err error // First error met during evaluation
+}
+
+func (e *evaluation) fail(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// checkAll reports whether all parts match, each evaluated in its own state. A failing part alone decides
+// the result, so only its errors are kept; when all parts match, the first error of any of them is.
+func checkAll(parts int, check func(i int, state *evaluation) bool, state *evaluation) bool {
+	var passed error
+	for i := 0; i < parts; i++ {
+		part := &evaluation{}
+		if !check(i, part) {
+			state.fail(part.err)
+			return false
+		}
+		if passed == nil {
+			passed = part.err
+		}
+	}
+	state.fail(passed)
+	return true
+}
+
+func (c *Conditions) check(instance any, condition any, state *evaluation) bool {
+	// A bare path like "{{user.active}}" is true when its value is truthy
+	if path, ok := condition.(string); ok {
+		return c.isTruthy(c.getValueByTemplate(path, instance))
+	}
+
 	// Recursively check conditions if it's a slice, treating it as an AND condition
 	if conditions, ok := condition.([]any); ok {
-		for _, cond := range conditions {
-			if !c.Check(instance, cond) {
-				return false
-			}
-		}
-		return true
+		return checkAll(len(conditions), func(i int, part *evaluation) bool {
+			return c.check(instance, conditions[i], part)
+		}, state)
 	}
 
-	// Handle condition maps
-	if condMap, ok := condition.(map[string]any); ok {
-		for key, value := range condMap {
-			valueKind := reflect.ValueOf(value).Kind()
-			if operator, exists := stringToSimpleOperator[key]; exists {
-				return c.checkSimpleOperator(operator, value, instance)
-			} else if operator, exists := stringToLogicOperator[key]; exists {
-				return c.checkLogicOperator(operator, value, instance)
-			} else if valueKind == reflect.Map || valueKind == reflect.Struct {
-				result, err := c.checkCommonOperator(key, value, instance)
-				return err == nil && result
-			} else {
-				return reflect.DeepEqual(c.getValueByTemplate(key, instance), c.getValueByTemplate(value, instance))
-			}
+	// Handle condition maps, all keys of which must match
+	if condMap, ok := condition.(map[string]any); ok && len(condMap) > 0 {
+		keys := make([]string, 0, len(condMap))
+		for key := range condMap {
+			keys = append(keys, key)
 		}
+		return checkAll(len(keys), func(i int, part *evaluation) bool {
+			return c.checkKey(keys[i], condMap[keys[i]], instance, part)
+		}, state)
 	}
 
 	return false
 }
 
+// checkKey checks a single key of a condition map.
+func (c *Conditions) checkKey(key string, value any, instance any, state *evaluation) bool {
+	valueKind := reflect.ValueOf(value).Kind()
+	if operator, exists := stringToSimpleOperator[key]; exists {
+		return c.checkSimpleOperator(operator, value, instance)
+	} else if operator, exists := stringToLogicOperator[key]; exists {
+		return c.checkLogicOperator(operator, value, instance, state)
+	} else if valueKind == reflect.Map || valueKind == reflect.Struct {
+		result, err := c.checkCommonOperator(key, value, instance)
+		if err != nil {
+			state.fail(fmt.Errorf("%s: %w", key, err))
+		}
+		// Only errors of elements that did not match come with a true result
+		return result
+	} else {
+		return c.valuesEqual(c.prepareText(c.getValueByTemplate(key, instance)), c.prepareText(c.getValueByTemplate(value, instance)))
+	}
+}
+
 func (c *Conditions) checkSimpleOperator(operator SimpleOperatorsEnum, value any, instance any) bool {
 	fact := c.getValueByTemplate(value, instance)
 	switch operator {
@@ -50,107 +109,126 @@ func (c *Conditions) checkSimpleOperator(operator SimpleOperatorsEnum, value any
 	case EXIST:
 		return fact != nil
 	case EMPTY:
-		v := reflect.ValueOf(fact)
-		switch v.Kind() {
-		case reflect.Array, reflect.Slice, reflect.String, reflect.Map:
-			return v.Len() == 0
-		default:
-			// TODO check for empty struct
-			return false
-		}
+		return isEmpty(fact)
 	case BLANK:
-		if fact == nil || reflect.ValueOf(fact).IsNil() {
-			return true
-		}
-		v := reflect.ValueOf(fact)
-		switch v.Kind() {
-		case reflect.Array, reflect.Slice, reflect.String:
-			return v.Len() == 0
-		case reflect.Bool:
-			return !v.Bool()
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return v.Int() == 0
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return v.Uint() == 0
-		case reflect.Float32, reflect.Float64:
-			return v.Float() == 0
-		case reflect.Map:
-			return v.Len() == 0
-		default:
-			return false // throw an error
-		}
+		return c.isBlank(fact)
 	case TRULY:
-		return fact == true
+		return c.isTruthy(fact)
 	case FALSY:
-		return fact == false
+		return c.isFalsy(fact)
+	case ZERO:
+		return isZero(fact)
 	default:
 		return false
 	}
 }
 
 func (c *Conditions) checkCommonOperator(key string, value any, instance any) (bool, error) {
-	fact := c.getValueByTemplate(key, instance)
+	return c.checkFactOperators(key, c.getValueByTemplate(key, instance), value, instance)
+}
+
+// checkFactOperators applies the common operators in value to an already resolved fact.
+// The key is only used to describe the fact in error messages, and the instance is the one the fact was
+// resolved from, which sub-conditions of list operators reach as $parent.
+// sortedOperators returns the operators of an operator map in the order they are checked: type checks
+// first, so that they guard the operators after them, then the others by name. The first operator
+// that does not match decides the result, so a fixed order keeps results and errors the same every time.
+func sortedOperators(conditionMap map[string]any) []string {
+	operators := make([]string, 0, len(conditionMap))
+	for operator := range conditionMap {
+		operators = append(operators, operator)
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		iType, jType := isTypeOperator(operators[i]), isTypeOperator(operators[j])
+		if iType != jType {
+			return iType
+		}
+		return operators[i] < operators[j]
+	})
+	return operators
+}
+
+func isTypeOperator(operator string) bool {
+	_, ok := typeShorthands[CommonOperatorsEnum(operator)]
+	return ok || CommonOperatorsEnum(operator) == TYPE
+}
 
+func (c *Conditions) checkFactOperators(key string, fact any, value any, instance any) (bool, error) {
 	// Ensure that value is a map containing our conditions.
 	conditionMap, ok := value.(map[string]any)
 	if !ok {
 		return false, fmt.Errorf("expected condition to be a map, got %T", value)
 	}
 
-	for operator, conditionValue := range conditionMap {
+	rawFact := fact
+	var reported error // Errors of elements that did not match, which do not decide the result
+
+	for _, operator := range sortedOperators(conditionMap) {
+		conditionValue := conditionMap[operator]
+		fact = rawFact
+		if textOperators[CommonOperatorsEnum(operator)] {
+			fact = c.prepareText(fact)
+			conditionValue = c.prepareText(conditionValue)
+		}
+
 		switch CommonOperatorsEnum(operator) {
 		case EQ:
-			if !reflect.DeepEqual(fact, conditionValue) {
+			if !c.valuesEqual(fact, conditionValue) {
 				return false, nil
 			}
 		case NE:
-			if reflect.DeepEqual(fact, conditionValue) {
+			if c.valuesEqual(fact, conditionValue) {
 				return false, nil
 			}
 		case LT, GT, LTE, GTE:
-			result, err := compareNumbersOrDates(fact, conditionValue)
+			result, err := c.compare(fact, conditionValue)
 			if err != nil {
 				return false, err
 			}
 
+			var matched bool
 			switch CommonOperatorsEnum(operator) {
 			case LT:
-				return result == -1, nil
+				matched = result == -1
 			case GT:
-				return result == 1, nil
+				matched = result == 1
 			case LTE:
-				return result <= 0, nil
+				matched = result <= 0
 			case GTE:
-				return result >= 0, nil
+				matched = result >= 0
+			}
+			if !matched {
+				return false, nil
 			}
 		case IN:
 			list, ok := reflect.ValueOf(conditionValue).Interface().([]string)
 			if !ok {
 				return false, fmt.Errorf("expected value to be a string array")
 			}
-			return contains(list, fact.(string)), nil
+			str, ok := fact.(string)
+			if !ok {
+				return false, fmt.Errorf("expected string for instance value, got %T", fact)
+			}
+			if !contains(list, str) {
+				return false, nil
+			}
 		case NI:
 			list, ok := reflect.ValueOf(conditionValue).Interface().([]string)
 			if !ok {
 				return false, fmt.Errorf("expected value to be a string array")
 			}
-			return !contains(list, fact.(string)), nil
-		case RE:
-			pattern, ok := conditionValue.(string)
-			if !ok {
-				return false, fmt.Errorf("expected string for $re operator, got %T", conditionValue)
-			}
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				return false, err
-			}
 			str, ok := fact.(string)
 			if !ok {
-				return false, fmt.Errorf("expected string for regex match, got %T", fact)
+				return false, fmt.Errorf("expected string for instance value, got %T", fact)
 			}
-			if !re.MatchString(str) {
+			if contains(list, str) {
 				return false, nil
 			}
+		case RE:
+			matched, err := c.checkRegex(fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
 		case SW:
 			prefix, ok := conditionValue.(string)
 			if !ok {
@@ -160,7 +238,9 @@ func (c *Conditions) checkCommonOperator(key string, value any, instance any) (b
 			if !ok {
 				return false, fmt.Errorf("expected string for instance value, got %T", fact)
 			}
-			return strings.HasPrefix(str, prefix), nil
+			if !strings.HasPrefix(str, prefix) {
+				return false, nil
+			}
 		case EW:
 			prefix, ok := conditionValue.(string)
 			if !ok {
@@ -170,25 +250,77 @@ func (c *Conditions) checkCommonOperator(key string, value any, instance any) (b
 			if !ok {
 				return false, fmt.Errorf("expected string for instance value, got %T", fact)
 			}
-			return strings.HasSuffix(str, prefix), nil
+			if !strings.HasSuffix(str, prefix) {
+				return false, nil
+			}
+		case CONTAINS, IEQ, ISW, IEW, ICONTAINS, LIKE, ILIKE, GLOB, LEN:
+			matched, err := c.checkStringOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case WITHINLAST, OLDERTHAN, SAMEDAY, WEEKDAY, AGE:
+			matched, err := c.checkDateOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case TIMEWINDOW, CRON, BUSINESSHOURS:
+			matched, err := c.checkScheduleOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case QTYEQ, QTYLT, QTYGT, QTYLTE, QTYGTE, QTYBETWEEN:
+			matched, err := c.checkQuantityOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case SEMVERGT, SEMVERLT, SEMVERBETWEEN, SEMVERSATISFIES:
+			matched, err := c.checkSemverOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case CIDR, IPV4, IPV6, IPPRIVATE, IPRANGE:
+			matched, err := c.checkNetworkOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case GEOWITHIN, GEONEAR, GEOBOX:
+			matched, err := c.checkGeoOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case FORMAT:
+			matched, err := c.checkFormat(fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case SCHEMA:
+			matched, err := c.checkSchema(fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case TYPE, ISSTRING, ISNUMBER, ISINTEGER, ISBOOL, ISARRAY, ISOBJECT, ISNULL, ISTIME:
+			matched, err := c.checkType(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
 		case INCL, HAS:
-			return isInCollection(fact, conditionValue), nil
+			if !isInCollection(fact, conditionValue) {
+				return false, nil
+			}
 		case EXCL:
-			return !isInCollection(fact, conditionValue), nil
-		case POWER:
-			// TODO: update for uint64 case
-			numValue, ok := toFloat64(fact)
-			if !ok {
-				return false, fmt.Errorf("expected numeric type for instance value, got %T", fact)
+			if isInCollection(fact, conditionValue) {
+				return false, nil
 			}
-			powerValue, ok := toFloat64(conditionValue)
-			if !ok {
-				return false, fmt.Errorf("expected numeric type for condition value, got %T", conditionValue)
+		case MOD, APPROX, FINITE, NAN:
+			matched, err := c.checkNumericOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case POWER, BITSALLSET, BITSANYSET, BITSALLCLEAR, BITSANYCLEAR:
+			matched, err := c.checkBitsOperator(CommonOperatorsEnum(operator), fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
 			}
-			num := int(numValue)
-			power := int(powerValue)
-
-			return (num & power) != 0, nil
 		case BETWEEN:
 			// First, ensure conditionValue can be treated as a slice of any.
 			val := reflect.ValueOf(conditionValue)
@@ -201,118 +333,89 @@ func (c *Conditions) checkCommonOperator(key string, value any, instance any) (b
 			upperBound := val.Index(1).Interface()
 
 			// Perform the comparisons.
-			compLower, err := compareNumbersOrDates(fact, lowerBound)
+			compLower, err := c.compare(fact, lowerBound)
 			if err != nil || compLower == -1 {
 				return false, err // If fact is less than the lower bound, or an error occurred.
 			}
 
-			compUpper, err := compareNumbersOrDates(fact, upperBound)
+			compUpper, err := c.compare(fact, upperBound)
 			if err != nil || compUpper == 1 {
 				return false, err // If fact is greater than the upper bound, or an error occurred.
 			}
-
-			return true, nil
-		case SOME:
-			factVal := reflect.ValueOf(fact)
-			if factVal.Kind() != reflect.Slice {
-				return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
-			}
-
-			conditionVal := reflect.ValueOf(conditionValue)
-
-			// Handle conditionValue as a slice
-			if conditionVal.Kind() == reflect.Slice {
-				// Iterate over each item in the conditionValue slice.
-				for i := 0; i < conditionVal.Len(); i++ {
-					conditionItem := conditionVal.Index(i).Interface()
-
-					// Check if conditionItem is in factVal slice.
-					for j := 0; j < factVal.Len(); j++ {
-						factItem := factVal.Index(j).Interface()
-						if reflect.DeepEqual(factItem, conditionItem) {
-							return true, nil // Found matching element.
-						}
-					}
-				}
-				return false, nil // No matching elements found.
-			} else { // Handle single condition value
-				singleCondition := conditionVal.Interface()
-				for j := 0; j < factVal.Len(); j++ {
-					factItem := factVal.Index(j).Interface()
-					if reflect.DeepEqual(factItem, singleCondition) {
-						return true, nil // Found matching element.
-					}
-				}
-				return false, nil // No matching element found.
-			}
-		case EVERY, NOONE:
-			factVal := reflect.ValueOf(fact)
-			if factVal.Kind() != reflect.Slice {
-				return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
-			}
-
-			conditionVal := reflect.ValueOf(conditionValue)
-			fmt.Printf("conditionVal: %v\n", conditionVal)
-
-			// Handle conditionValue as a slice
-			if conditionVal.Kind() == reflect.Slice {
-				var result bool
-				// Iterate over each item in the conditionValue slice.
-				for i := 0; i < conditionVal.Len(); i++ {
-					result = false
-					conditionItem := conditionVal.Index(i).Interface()
-
-					// Check if conditionItem is in factVal slice.
-					for j := 0; j < factVal.Len(); j++ {
-						factItem := factVal.Index(j).Interface()
-						eq := reflect.DeepEqual(factItem, conditionItem)
-
-						if CommonOperatorsEnum(operator) == EVERY && eq || CommonOperatorsEnum(operator) == NOONE && !eq {
-							result = true
-						}
-						if CommonOperatorsEnum(operator) == NOONE && eq {
-							return false, nil
-						}
-					}
-
-					if !result {
-						return false, nil
-					}
-				}
-
-				return result, nil // No matching elements found.
-			} else { // Handle single condition value
-				conditionItem := conditionVal.Interface()
-
-				for j := 0; j < factVal.Len(); j++ {
-					factItem := factVal.Index(j).Interface()
-					eq := reflect.DeepEqual(factItem, conditionItem)
-
-					if CommonOperatorsEnum(operator) == NOONE && eq {
-						return false, nil
-					}
-					if CommonOperatorsEnum(operator) == EVERY && eq {
-						return true, nil
-					}
-				}
-
-				if CommonOperatorsEnum(operator) == NOONE {
-					return true, nil
-				} else {
-					return false, nil // No matching element found.
-				}
+		case ELEMMATCH:
+			matched, err := c.checkElementOperator(CommonOperatorsEnum(operator), key, fact, conditionValue, instance)
+			if !keepElementError(matched, err, &reported) {
+				return false, err
+			}
+		case SOME, EVERY, NOONE:
+			matched, err := c.checkListOperator(CommonOperatorsEnum(operator), key, fact, conditionValue, instance)
+			if !keepElementError(matched, err, &reported) {
+				return false, err
+			}
+		case SIZE, SUM, AVG, MIN, MAX, COUNT:
+			matched, err := c.checkAggregateOperator(CommonOperatorsEnum(operator), key, fact, conditionValue, instance)
+			if !keepElementError(matched, err, &reported) {
+				return false, err
+			}
+		case HASKEY, HASALLKEYS, HASANYKEY, HASVALUE, ANYVALUE, ALLVALUES:
+			matched, err := c.checkMapOperator(CommonOperatorsEnum(operator), key, fact, conditionValue, instance)
+			if !keepElementError(matched, err, &reported) {
+				return false, err
+			}
+		case UNIQUE, SORTED, DISTINCTCOUNT:
+			matched, err := c.checkSequenceOperator(CommonOperatorsEnum(operator), key, fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
+			}
+		case ALL, SUPERSET, SUBSET, DISJOINT, INTERSECTS, SETEQUALS:
+			matched, err := c.checkSetOperator(CommonOperatorsEnum(operator), key, fact, conditionValue)
+			if err != nil || !matched {
+				return false, err
 			}
 		default:
 			return false, fmt.Errorf("unhandled operator %s", operator)
 		}
 	}
 
-	return true, nil
+	return true, reported
+}
+
+// compare orders two values after applying the coercion policy, using the text options when both are
+// plain text strings. Relative time expressions like "now-30d" are resolved when the first value is a time.
+func (c *Conditions) compare(v1, v2 any) (int, error) {
+	v1, v2 = c.coercePair(v1, v2)
+
+	if expr, ok := v2.(string); ok {
+		if _, err := toTimeFact(v1); err == nil {
+			if t, ok := c.resolveRelativeTime(expr); ok {
+				v2 = t
+			}
+		}
+	}
+
+	if result, ok := c.compareText(v1, v2); ok {
+		return result, nil
+	}
+	return compareNumbersOrDates(v1, v2)
 }
 
-func (c *Conditions) checkLogicOperator(operator LogicOperatorsEnum, value any, instance any) bool {
+// checkDerivedNumber checks a number derived from the fact, like a length or an age. The operand is
+// either a number for an exact match or a map of common operators applied to the derived number.
+func (c *Conditions) checkDerivedNumber(operator CommonOperatorsEnum, derived any, conditionValue any) (bool, error) {
+	if reflect.ValueOf(conditionValue).Kind() == reflect.Map {
+		return c.checkFactOperators(string(operator), derived, conditionValue, nil)
+	}
+
+	if !isNumber(conditionValue) {
+		return false, fmt.Errorf("expected number or operator map for %s operator, got %T", operator, conditionValue)
+	}
+	result, ok := compareNumbers(derived, conditionValue)
+	return ok && result == 0, nil
+}
+
+func (c *Conditions) checkLogicOperator(operator LogicOperatorsEnum, value any, instance any, state *evaluation) bool {
 	// Convert value to a slice of conditions
-	var conditions []map[string]any
+	var conditions []any
 
 	// Use reflection to handle both []any and []map[string]any cases gracefully
 	val := reflect.ValueOf(value)
@@ -320,12 +423,12 @@ func (c *Conditions) checkLogicOperator(operator LogicOperatorsEnum, value any,
 		for i := 0; i < val.Len(); i++ {
 			item := val.Index(i).Interface()
 
-			// Attempt to assert each item's type to a map[string]any
-			if cond, ok := item.(map[string]any); ok {
+			// Each item is either a condition map or a bare path
+			switch cond := item.(type) {
+			case map[string]any, string:
 				conditions = append(conditions, cond)
-			} else {
-				// Handle error or unexpected type
-				fmt.Printf("Unexpected type in conditions slice: got %T\n", item)
+			default:
+				state.fail(fmt.Errorf("unexpected type in %s conditions slice: got %T", operator, item))
 				return false
 			}
 		}
@@ -337,39 +440,38 @@ func (c *Conditions) checkLogicOperator(operator LogicOperatorsEnum, value any,
 			conditions = append(conditions, singleCondition)
 		}
 	} else {
-		fmt.Printf("Unexpected type for value: got %T\n", value)
+		state.fail(fmt.Errorf("unexpected type for %s value: got %T", operator, value))
 		return false
 	}
 
 	switch operator {
 	case OR:
+		// The first matching branch decides, so errors of the other branches only count when none matches
+		failed := &evaluation{}
 		for _, cond := range conditions {
-			fmt.Println("OR condition: ", cond)
-			if c.Check(instance, cond) {
-				fmt.Println("result: ", true)
+			branch := &evaluation{}
+			if c.check(instance, cond, branch) {
+				state.fail(branch.err)
 				return true
 			}
-			fmt.Println("result: ", false)
+			failed.fail(branch.err)
 		}
+		state.fail(failed.err)
 		return false
 	case XOR:
 		trueCount := 0
 		for _, cond := range conditions {
-			if c.Check(instance, cond) {
+			if c.check(instance, cond, state) {
 				trueCount++
 			}
 		}
 		return trueCount == 1
 	case AND:
-		for _, cond := range conditions {
-			if !c.Check(instance, cond) {
-				return false
-			}
-		}
-		return true
+		return c.check(instance, conditions, state)
 	case NOT:
+		// Errors under $not are left out, since a failure there turns into a match
 		for _, cond := range conditions {
-			if c.Check(instance, cond) {
+			if c.check(instance, cond, &evaluation{}) {
 				return false
 			}
 		}
@@ -391,6 +493,9 @@ func (c *Conditions) getValueByTemplate(value any, instance any) any {
 		return c.getTemplateString(valueStr[2:], instance)
 	} else if strings.HasPrefix(valueStr, "{{") && strings.HasSuffix(valueStr, "}}") {
 		valueStr = strings.TrimSpace(valueStr[2 : len(valueStr)-2])
+		if valueStr == nowTemplate {
+			return c.currentTime()
+		}
 		return c.getValueByChain(valueStr, instance)
 	}
 
@@ -421,10 +526,18 @@ func (c *Conditions) getTemplateString(value string, instance any) string {
 
 // getValueByChain retrieves a value from an instance based on a "dot" path (e.g., "a.b.c").
 func (c *Conditions) getValueByChain(param string, instance any) any {
+	if scope, ok := instance.(*elementScope); ok {
+		return c.getScopedValue(param, scope)
+	}
+
 	chain := strings.Split(param, ".")
 	var result any
 
-	for _, step := range chain {
+	for i, step := range chain {
+		if step == wildcardStep {
+			return c.getWildcardValues(instance, chain[i+1:])
+		}
+
 		instanceValue := reflect.ValueOf(instance)
 
 		if instanceValue.Kind() == reflect.Pointer {
@@ -0,0 +1,258 @@
+package conditions
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// nowTemplate is the template path that resolves to the current time of the injected clock, as in "{{$now}}".
+const nowTemplate = "$now"
+
+// WithClock sets the function used to read the current time, which makes relative dates deterministic in tests.
+func WithClock(now func() time.Time) Option {
+	return func(c *Conditions) {
+		c.now = now
+	}
+}
+
+// WithLocation sets the time zone used for calendar calculations like days, weeks and ages.
+func WithLocation(location *time.Location) Option {
+	return func(c *Conditions) {
+		c.location = location
+	}
+}
+
+// currentTime returns the current time of the injected clock in the configured location.
+func (c *Conditions) currentTime() time.Time {
+	return c.now().In(c.location)
+}
+
+// calendarSpan is a signed amount of calendar time, like "1y2mo" or "30d".
+// Years, months and days are added with time.AddDate, so they follow the calendar and DST changes.
+type calendarSpan struct {
+	years, months, days int
+	duration            time.Duration
+}
+
+func (s calendarSpan) addTo(t time.Time, sign int) time.Time {
+	return t.AddDate(sign*s.years, sign*s.months, sign*s.days).Add(time.Duration(sign) * s.duration)
+}
+
+// parseCalendarSpan parses a sequence of number and unit pairs, e.g. "30d", "1y6mo" or "1h30m".
+// Supported units are s, m, h, d, w, mo and y.
+func parseCalendarSpan(value string) (calendarSpan, error) {
+	var span calendarSpan
+	rest := strings.TrimSpace(value)
+	if rest == "" {
+		return span, fmt.Errorf("empty time span")
+	}
+
+	for rest != "" {
+		i := 0
+		for i < len(rest) && unicode.IsDigit(rune(rest[i])) {
+			i++
+		}
+		if i == 0 {
+			return span, fmt.Errorf("invalid time span %q", value)
+		}
+		amount, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return span, fmt.Errorf("invalid time span %q: %w", value, err)
+		}
+		rest = rest[i:]
+
+		j := 0
+		for j < len(rest) && unicode.IsLetter(rune(rest[j])) {
+			j++
+		}
+		switch rest[:j] {
+		case "s":
+			span.duration += time.Duration(amount) * time.Second
+		case "m":
+			span.duration += time.Duration(amount) * time.Minute
+		case "h":
+			span.duration += time.Duration(amount) * time.Hour
+		case "d":
+			span.days += amount
+		case "w":
+			span.days += amount * 7
+		case "mo":
+			span.months += amount
+		case "y":
+			span.years += amount
+		default:
+			return span, fmt.Errorf("invalid unit %q in time span %q", rest[:j], value)
+		}
+		rest = rest[j:]
+	}
+	return span, nil
+}
+
+// resolveRelativeTime resolves expressions like "now", "now-30d", "today+1d" or "startOfMonth".
+// Anchors are now, today, startOfDay, startOfWeek (Monday), startOfMonth and startOfYear,
+// optionally followed by one or more signed time spans.
+func (c *Conditions) resolveRelativeTime(expr string) (time.Time, bool) {
+	end := strings.IndexAny(expr, "+-")
+	if end < 0 {
+		end = len(expr)
+	}
+
+	now := c.currentTime()
+	var t time.Time
+	switch strings.TrimSpace(expr[:end]) {
+	case "now":
+		t = now
+	case "today", "startOfDay":
+		t = startOfDay(now)
+	case "startOfWeek":
+		daysSinceMonday := (int(now.Weekday()) + 6) % 7
+		t = startOfDay(now).AddDate(0, 0, -daysSinceMonday)
+	case "startOfMonth":
+		t = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	case "startOfYear":
+		t = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}, false
+	}
+
+	rest := expr[end:]
+	for rest != "" {
+		sign := 1
+		if rest[0] == '-' {
+			sign = -1
+		}
+		rest = rest[1:]
+		next := strings.IndexAny(rest, "+-")
+		if next < 0 {
+			next = len(rest)
+		}
+		span, err := parseCalendarSpan(rest[:next])
+		if err != nil {
+			return time.Time{}, false
+		}
+		t = span.addTo(t, sign)
+		rest = rest[next:]
+	}
+	return t, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// toTimeOperand converts an operand to a time: a time.Time, a relative expression, an RFC 3339 string or a date like "2024-05-01".
+func (c *Conditions) toTimeOperand(value any) (time.Time, error) {
+	if str, ok := value.(string); ok {
+		if t, ok := c.resolveRelativeTime(str); ok {
+			return t, nil
+		}
+		if t, err := time.ParseInLocation(time.DateOnly, str, c.location); err == nil {
+			return t, nil
+		}
+	}
+	return toTime(value)
+}
+
+// toTimeFact converts a fact to a time, accepting time.Time values and RFC 3339 strings.
+func toTimeFact(fact any) (time.Time, error) {
+	switch v := fact.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected time.Time or RFC 3339 string for instance value, got %T", fact)
+}
+
+// checkDateOperator evaluates the relative and calendar date operators against a time fact.
+func (c *Conditions) checkDateOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	t, err := toTimeFact(fact)
+	if err != nil {
+		return false, err
+	}
+	t = t.In(c.location)
+	now := c.currentTime()
+
+	switch operator {
+	case WITHINLAST, OLDERTHAN:
+		spanStr, ok := conditionValue.(string)
+		if !ok {
+			return false, fmt.Errorf("expected time span string for %s operator, got %T", operator, conditionValue)
+		}
+		span, err := parseCalendarSpan(spanStr)
+		if err != nil {
+			return false, err
+		}
+		boundary := span.addTo(now, -1)
+		if operator == OLDERTHAN {
+			return t.Before(boundary), nil
+		}
+		return !t.Before(boundary) && !t.After(now), nil
+	case SAMEDAY:
+		other, err := c.toTimeOperand(conditionValue)
+		if err != nil {
+			return false, err
+		}
+		other = other.In(c.location)
+		return t.Year() == other.Year() && t.YearDay() == other.YearDay(), nil
+	case WEEKDAY:
+		val := reflect.ValueOf(conditionValue)
+		if val.Kind() != reflect.Slice {
+			return matchesWeekday(t.Weekday(), conditionValue)
+		}
+		for i := 0; i < val.Len(); i++ {
+			matched, err := matchesWeekday(t.Weekday(), val.Index(i).Interface())
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	case AGE:
+		return c.checkDerivedNumber(AGE, yearsBetween(t, now), conditionValue)
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
+
+// yearsBetween returns the number of full years from t to now, as used for ages and anniversaries.
+func yearsBetween(t, now time.Time) int {
+	years := now.Year() - t.Year()
+	if now.Month() < t.Month() || now.Month() == t.Month() && now.Day() < t.Day() {
+		years--
+	}
+	return years
+}
+
+// matchesWeekday compares a weekday with a name ("monday", "mon") or a number (0 is Sunday).
+func matchesWeekday(weekday time.Weekday, value any) (bool, error) {
+	if name, ok := value.(string); ok {
+		expected, err := parseWeekday(name)
+		if err != nil {
+			return false, err
+		}
+		return weekday == expected, nil
+	}
+
+	number, ok := toFloat64(value)
+	if !ok || number < 0 || number > 6 {
+		return false, fmt.Errorf("expected weekday name or number 0-6 for $weekday operator, got %v", value)
+	}
+	return int(weekday) == int(number), nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if lower == full || lower == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", name)
+}
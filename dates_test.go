@@ -0,0 +1,111 @@
+package conditions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateOperators(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	// Wednesday, 15 May 2024, 10:30 in Berlin
+	now := time.Date(2024, 5, 15, 10, 30, 0, 0, berlin)
+	cond := NewConditions(WithClock(func() time.Time { return now }), WithLocation(berlin))
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $withinLast operator true",
+			condition: map[string]any{"{{signedUp}}": map[string]any{"$withinLast": "30d"}},
+			instance:  map[string]any{"signedUp": now.AddDate(0, 0, -29)},
+			want:      true,
+		},
+		{
+			name:      "Test $withinLast operator false",
+			condition: map[string]any{"{{signedUp}}": map[string]any{"$withinLast": "30d"}},
+			instance:  map[string]any{"signedUp": now.AddDate(0, 0, -31)},
+			want:      false,
+		},
+		{
+			name:      "Test $withinLast operator with RFC 3339 string fact",
+			condition: map[string]any{"{{signedUp}}": map[string]any{"$withinLast": "1w"}},
+			instance:  map[string]any{"signedUp": "2024-05-10T08:00:00Z"},
+			want:      true,
+		},
+		{
+			name:      "Test $olderThan operator true",
+			condition: map[string]any{"{{lastLogin}}": map[string]any{"$olderThan": "1mo"}},
+			instance:  map[string]any{"lastLogin": time.Date(2024, 4, 1, 0, 0, 0, 0, berlin)},
+			want:      true,
+		},
+		{
+			name:      "Test $sameDay operator with today",
+			condition: map[string]any{"{{orderedAt}}": map[string]any{"$sameDay": "today"}},
+			instance:  map[string]any{"orderedAt": time.Date(2024, 5, 14, 23, 30, 0, 0, time.UTC)},
+			want:      true,
+		},
+		{
+			name:      "Test $sameDay operator with date string",
+			condition: map[string]any{"{{orderedAt}}": map[string]any{"$sameDay": "2024-05-14"}},
+			instance:  map[string]any{"orderedAt": now},
+			want:      false,
+		},
+		{
+			name:      "Test $weekday operator with name",
+			condition: map[string]any{"{{orderedAt}}": map[string]any{"$weekday": "wed"}},
+			instance:  map[string]any{"orderedAt": now},
+			want:      true,
+		},
+		{
+			name:      "Test $weekday operator with weekend list",
+			condition: map[string]any{"{{orderedAt}}": map[string]any{"$weekday": []any{"saturday", 0}}},
+			instance:  map[string]any{"orderedAt": now},
+			want:      false,
+		},
+		{
+			name:      "Test $age operator with nested operators",
+			condition: map[string]any{"{{birthday}}": map[string]any{"$age": map[string]any{"$gte": 18}}},
+			instance:  map[string]any{"birthday": time.Date(2006, 5, 15, 0, 0, 0, 0, berlin)},
+			want:      true,
+		},
+		{
+			name:      "Test $age operator day before birthday",
+			condition: map[string]any{"{{birthday}}": map[string]any{"$age": 18}},
+			instance:  map[string]any{"birthday": time.Date(2006, 5, 16, 0, 0, 0, 0, berlin)},
+			want:      false,
+		},
+		{
+			name:      "Test $gte operator with relative operand",
+			condition: map[string]any{"{{createdAt}}": map[string]any{"$gte": "startOfMonth"}},
+			instance:  map[string]any{"createdAt": time.Date(2024, 5, 1, 0, 0, 0, 0, berlin)},
+			want:      true,
+		},
+		{
+			name:      "Test $between operator with relative operands",
+			condition: map[string]any{"{{createdAt}}": map[string]any{"$between": []string{"startOfWeek", "now+1d"}}},
+			instance:  map[string]any{"createdAt": time.Date(2024, 5, 12, 12, 0, 0, 0, berlin)},
+			want:      false,
+		},
+		{
+			name:      "Test $withinLast operator with non-time fact",
+			condition: map[string]any{"{{signedUp}}": map[string]any{"$withinLast": "30d"}},
+			instance:  map[string]any{"signedUp": 42},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
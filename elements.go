@@ -0,0 +1,159 @@
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	thisReference   = "$this"   // The list element a sub-condition is evaluated for
+	parentReference = "$parent" // The instance the enclosing condition was evaluated against
+	rootReference   = "$root"   // The instance passed to Check
+)
+
+// elementScope is the instance of a sub-condition evaluated for an element of a list. Paths resolve
+// against the element, except those starting with $this, $parent or $root.
+type elementScope struct {
+	element any
+	parent  any // Instance of the enclosing condition, an *elementScope itself for nested lists
+	root    any
+}
+
+func newElementScope(element any, parent any) *elementScope {
+	root := parent
+	if scope, ok := parent.(*elementScope); ok {
+		root = scope.root
+	}
+	return &elementScope{element: element, parent: parent, root: root}
+}
+
+// getScopedValue resolves a path inside a sub-condition.
+func (c *Conditions) getScopedValue(param string, scope *elementScope) any {
+	first, rest, _ := strings.Cut(param, ".")
+
+	var instance any
+	switch first {
+	case thisReference:
+		instance = scope.element
+	case parentReference:
+		instance = scope.parent
+	case rootReference:
+		instance = scope.root
+	default:
+		return c.getValueByChain(param, scope.element)
+	}
+
+	if rest == "" {
+		if parent, ok := instance.(*elementScope); ok {
+			return parent.element
+		}
+		return instance
+	}
+	return c.getValueByChain(rest, instance)
+}
+
+// isOperatorMap reports whether all keys of a condition are common operators, like {"$gte": 80}.
+// Such conditions apply to the element itself rather than to paths inside it.
+func isOperatorMap(condition map[string]any) bool {
+	for key := range condition {
+		if _, ok := stringToCommonOperator[key]; !ok {
+			return false
+		}
+	}
+	return len(condition) > 0
+}
+
+// elementError is an error met while evaluating a sub-condition for an element. The element does not
+// match, but the error does not decide the result of the operator on its own, so a single malformed
+// element neither aborts the operator nor makes its result depend on iteration order. Evaluate reports it.
+type elementError struct {
+	err error
+}
+
+func (e *elementError) Error() string {
+	return e.err.Error()
+}
+
+func (e *elementError) Unwrap() error {
+	return e.err
+}
+
+func asElementError(err error) error {
+	var elemErr *elementError
+	if err == nil || errors.As(err, &elemErr) {
+		return err
+	}
+	return &elementError{err: err}
+}
+
+// keepElementError reports whether an operator map can go on after an operator returned matched and err.
+// Errors of elements that did not match are kept in reported, since they do not decide the result.
+func keepElementError(matched bool, err error, reported *error) bool {
+	if !matched {
+		return false
+	}
+	if err != nil {
+		var elemErr *elementError
+		if !errors.As(err, &elemErr) {
+			return false
+		}
+		if *reported == nil {
+			*reported = err
+		}
+	}
+	return true
+}
+
+// matchElement evaluates a sub-condition for one element of a list or value of a map. Errors are returned
+// as an *elementError next to the result, which is false for an element the sub-condition failed for.
+func (c *Conditions) matchElement(key string, element any, condition map[string]any, instance any) (bool, error) {
+	if isOperatorMap(condition) {
+		matched, err := c.checkFactOperators(key, element, condition, instance)
+		return matched, asElementError(err)
+	}
+
+	state := &evaluation{}
+	matched := c.check(newElementScope(element, instance), condition, state)
+	return matched, asElementError(state.err)
+}
+
+// checkElementOperator handles $elemMatch, and $some, $every and $noone with a sub-condition operand.
+// The sub-condition is evaluated with each element as the instance. The error returned with the result is
+// the one of the element that decided it, or the first one when all elements did.
+func (c *Conditions) checkElementOperator(operator CommonOperatorsEnum, key string, fact any, conditionValue any, instance any) (bool, error) {
+	condition, ok := conditionValue.(map[string]any)
+	if !ok {
+		return false, fmt.Errorf("expected sub-condition map for %s operator, got %T", operator, conditionValue)
+	}
+	factVal := reflect.ValueOf(fact)
+	if factVal.Kind() != reflect.Slice && factVal.Kind() != reflect.Array {
+		return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
+	}
+
+	var reported error
+	for i := 0; i < factVal.Len(); i++ {
+		matched, err := c.matchElement(fmt.Sprintf("%s[%d]", key, i), factVal.Index(i).Interface(), condition, instance)
+		if reported == nil {
+			reported = err
+		}
+		switch operator {
+		case SOME, ELEMMATCH:
+			if matched {
+				return true, err
+			}
+		case EVERY:
+			if !matched {
+				return false, err
+			}
+		case NOONE:
+			if matched {
+				return false, err
+			}
+		default:
+			return false, fmt.Errorf("unhandled operator %s", operator)
+		}
+	}
+	return operator == EVERY || operator == NOONE, reported
+}
@@ -0,0 +1,209 @@
+package conditions
+
+import (
+	"testing"
+)
+
+func TestElementConditions(t *testing.T) {
+	cond := NewConditions()
+
+	order := map[string]any{
+		"currency": "EUR",
+		"limit":    150,
+		"items": []any{
+			map[string]any{"name": "Cable", "price": 12, "category": "accessories", "currency": "EUR"},
+			map[string]any{"name": "Monitor", "price": 240, "category": "electronics", "currency": "EUR"},
+		},
+		"scores": []int{78, 83, 91},
+		"customer": map[string]any{
+			"tier": "gold",
+			"addresses": []any{
+				map[string]any{"city": "Berlin", "tags": []string{"home", "billing"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name: "Test $some with sub-condition true",
+			condition: map[string]any{"{{items}}": map[string]any{"$some": map[string]any{
+				"{{price}}":    map[string]any{"$gt": 100},
+				"{{category}}": map[string]any{"$eq": "electronics"},
+			}}},
+			instance: order,
+			want:     true,
+		},
+		{
+			name: "Test $some with sub-condition false",
+			condition: map[string]any{"{{items}}": map[string]any{"$some": map[string]any{
+				"{{price}}":    map[string]any{"$gt": 100},
+				"{{category}}": map[string]any{"$eq": "accessories"},
+			}}},
+			instance: order,
+			want:     false,
+		},
+		{
+			name:      "Test $every with sub-condition",
+			condition: map[string]any{"{{items}}": map[string]any{"$every": map[string]any{"{{price}}": map[string]any{"$gt": 10}}}},
+			instance:  order,
+			want:      true,
+		},
+		{
+			name:      "Test $noone with sub-condition",
+			condition: map[string]any{"{{items}}": map[string]any{"$noone": map[string]any{"$blank": "{{name}}"}}},
+			instance:  order,
+			want:      true,
+		},
+		{
+			name:      "Test $every with operator map",
+			condition: map[string]any{"{{scores}}": map[string]any{"$every": map[string]any{"$gte": 70, "$lt": 100}}},
+			instance:  order,
+			want:      true,
+		},
+		{
+			name:      "Test $elemMatch with operator map",
+			condition: map[string]any{"{{scores}}": map[string]any{"$elemMatch": map[string]any{"$gte": 80, "$lt": 85}}},
+			instance:  order,
+			want:      true,
+		},
+		{
+			name:      "Test $elemMatch with operator map false",
+			condition: map[string]any{"{{scores}}": map[string]any{"$elemMatch": map[string]any{"$gte": 84, "$lt": 90}}},
+			instance:  order,
+			want:      false,
+		},
+		{
+			name: "Test $elemMatch with $this",
+			condition: map[string]any{"{{scores}}": map[string]any{"$elemMatch": map[string]any{
+				"$or": []any{
+					map[string]any{"{{$this}}": 91},
+					map[string]any{"{{$this}}": 92},
+				},
+			}}},
+			instance: order,
+			want:     true,
+		},
+		{
+			name: "Test $every with $parent reference",
+			condition: map[string]any{"{{items}}": map[string]any{"$every": map[string]any{
+				"{{currency}}": "{{$parent.currency}}",
+			}}},
+			instance: order,
+			want:     true,
+		},
+		{
+			name: "Test $some with $parent path",
+			condition: map[string]any{"{{items}}": map[string]any{"$some": map[string]any{
+				"{{price}}":         map[string]any{"$gt": 200},
+				"{{$parent.limit}}": map[string]any{"$lt": 100},
+			}}},
+			instance: order,
+			want:     false,
+		},
+		{
+			name: "Test nested $some with $root reference",
+			condition: map[string]any{"{{customer.addresses}}": map[string]any{"$some": map[string]any{
+				"{{tags}}": map[string]any{"$some": map[string]any{
+					"$and": []any{
+						map[string]any{"{{$this}}": map[string]any{"$eq": "billing"}},
+						map[string]any{"{{$root.customer.tier}}": map[string]any{"$eq": "gold"}},
+						map[string]any{"{{$parent.city}}": map[string]any{"$eq": "Berlin"}},
+					},
+				}},
+			}}},
+			instance: order,
+			want:     true,
+		},
+		{
+			name:      "Test $some with value list keeps equality",
+			condition: map[string]any{"{{scores}}": map[string]any{"$some": []int{83, 100}}},
+			instance:  order,
+			want:      true,
+		},
+		{
+			name:      "Test $elemMatch with non-list fact",
+			condition: map[string]any{"{{currency}}": map[string]any{"$elemMatch": map[string]any{"$eq": "EUR"}}},
+			instance:  order,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElementErrorsDoNotMatch(t *testing.T) {
+	cond := NewConditions()
+	// The second item has no price, so comparing its price fails.
+	instance := map[string]any{"items": []any{
+		map[string]any{"name": "Laptop", "price": 1200},
+		map[string]any{"name": "Gift card"},
+		map[string]any{"name": "Mouse", "price": 25},
+	}}
+	expensive := map[string]any{"{{price}}": map[string]any{"$gt": 100}}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "Test $some with an element that fails",
+			condition: map[string]any{"{{items}}": map[string]any{"$some": expensive}},
+			want:      true,
+		},
+		{
+			name:      "Test $elemMatch with an element that fails",
+			condition: map[string]any{"{{items}}": map[string]any{"$elemMatch": expensive}},
+			want:      true,
+		},
+		{
+			name:      "Test $every with an element that fails",
+			condition: map[string]any{"{{items}}": map[string]any{"$every": map[string]any{"{{price}}": map[string]any{"$gt": 0}}}},
+			want:      false,
+			wantErr:   true,
+		},
+		{
+			name:      "Test $noone with an element that fails",
+			condition: map[string]any{"{{items}}": map[string]any{"$noone": map[string]any{"{{price}}": map[string]any{"$gt": 5000}}}},
+			want:      true,
+			wantErr:   true,
+		},
+		{
+			name:      "Test $noone with a misspelled operator",
+			condition: map[string]any{"{{items}}": map[string]any{"$noone": map[string]any{"{{price}}": map[string]any{"$gtt": 100}}}},
+			want:      true,
+			wantErr:   true,
+		},
+		{
+			name:      "Test $noone with an operand of the wrong type",
+			condition: map[string]any{"{{items}}": map[string]any{"$noone": map[string]any{"{{price}}": map[string]any{"$gt": "100"}}}},
+			want:      true,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+			// The result must not depend on the order the elements are visited in
+			got, err := cond.Evaluate(instance, tt.condition)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("Evaluate() for %s = %v, %v, want %v and error %v", tt.name, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
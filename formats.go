@@ -0,0 +1,149 @@
+package conditions
+
+import (
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FormatValidator reports whether a string is valid for a named format.
+type FormatValidator func(value string) bool
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	e164Pattern     = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	hostnamePattern = regexp.MustCompile(`^(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)(?:\.(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?))*$`)
+	ibanPattern     = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+)
+
+// builtinFormats are the validators available to $format on every Conditions instance.
+var builtinFormats = map[string]FormatValidator{
+	"email":       isEmail,
+	"uuid":        uuidPattern.MatchString,
+	"url":         isURL,
+	"date":        isLayout(time.DateOnly),
+	"date-time":   isLayout(time.RFC3339Nano),
+	"time":        isLayout(time.TimeOnly),
+	"e164":        e164Pattern.MatchString,
+	"iban":        isIBAN,
+	"credit-card": isCreditCard,
+	"ipv4":        isIP(netip.Addr.Is4),
+	"ipv6":        isIP(netip.Addr.Is6),
+	"hostname":    isHostname,
+}
+
+// WithFormat registers a named format for the $format operator, replacing a built-in format of the same name.
+func WithFormat(name string, validator FormatValidator) Option {
+	return func(c *Conditions) {
+		if c.formats == nil {
+			c.formats = make(map[string]FormatValidator)
+		}
+		c.formats[name] = validator
+	}
+}
+
+// formatValidator looks up a custom format first and falls back to the built-in ones.
+func (c *Conditions) formatValidator(name string) (FormatValidator, bool) {
+	if validator, ok := c.formats[name]; ok {
+		return validator, true
+	}
+	validator, ok := builtinFormats[name]
+	return validator, ok
+}
+
+// checkFormat validates a string fact against a named format.
+func (c *Conditions) checkFormat(fact any, conditionValue any) (bool, error) {
+	name, ok := conditionValue.(string)
+	if !ok {
+		return false, fmt.Errorf("expected format name for $format operator, got %T", conditionValue)
+	}
+	validator, ok := c.formatValidator(name)
+	if !ok {
+		return false, fmt.Errorf("unknown format %q", name)
+	}
+	str, ok := fact.(string)
+	if !ok {
+		return false, fmt.Errorf("expected string for instance value, got %T", fact)
+	}
+	return validator(str), nil
+}
+
+func isEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value && strings.Contains(value[strings.LastIndex(value, "@"):], ".")
+}
+
+func isURL(value string) bool {
+	u, err := url.ParseRequestURI(value)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func isLayout(layout string) FormatValidator {
+	return func(value string) bool {
+		_, err := time.Parse(layout, value)
+		return err == nil
+	}
+}
+
+func isIP(family func(netip.Addr) bool) FormatValidator {
+	return func(value string) bool {
+		addr, err := netip.ParseAddr(value)
+		return err == nil && family(addr)
+	}
+}
+
+func isHostname(value string) bool {
+	return len(value) <= 253 && hostnamePattern.MatchString(value)
+}
+
+// isIBAN checks the structure and the ISO 13616 mod 97 check digits. Spaces are ignored.
+func isIBAN(value string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+	if !ibanPattern.MatchString(iban) {
+		return false
+	}
+
+	// Move the country code and check digits to the end and replace letters with numbers (A = 10, ..., Z = 35).
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// isCreditCard checks a 12 to 19 digit card number with the Luhn algorithm. Spaces and hyphens are ignored.
+func isCreditCard(value string) bool {
+	number := strings.NewReplacer(" ", "", "-", "").Replace(value)
+	if len(number) < 12 || len(number) > 19 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
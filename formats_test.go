@@ -0,0 +1,57 @@
+package conditions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatOperator(t *testing.T) {
+	cond := NewConditions(
+		WithFormat("sku", func(value string) bool { return strings.HasPrefix(value, "SKU-") && len(value) == 10 }),
+		WithFormat("uuid", func(value string) bool { return value == "custom" }),
+	)
+
+	tests := []struct {
+		format string
+		value  any
+		want   bool
+	}{
+		{"email", "john.doe@example.com", true},
+		{"email", "John Doe <john@example.com>", false},
+		{"email", "john@localhost", false},
+		{"url", "https://example.com/path?q=1", true},
+		{"url", "example.com/path", false},
+		{"date", "2024-02-29", true},
+		{"date", "2023-02-29", false},
+		{"date-time", "2024-05-01T12:00:00+02:00", true},
+		{"time", "25:00:00", false},
+		{"e164", "+4915112345678", true},
+		{"e164", "015112345678", false},
+		{"iban", "DE89 3704 0044 0532 0130 00", true},
+		{"iban", "DE89 3704 0044 0532 0130 01", false},
+		{"credit-card", "4111 1111 1111 1111", true},
+		{"credit-card", "4111-1111-1111-1112", false},
+		{"ipv4", "192.168.0.1", true},
+		{"ipv6", "192.168.0.1", false},
+		{"hostname", "api.example.com", true},
+		{"hostname", "-bad-.example.com", false},
+		{"sku", "SKU-123456", true},
+		{"uuid", "123e4567-e89b-12d3-a456-426614174000", false}, // Overridden by the custom format
+		{"unknown", "anything", false},
+		{"email", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			condition := map[string]any{"{{value}}": map[string]any{"$format": tt.format}}
+			instance := map[string]any{"value": tt.value}
+			if got := cond.Check(instance, condition); got != tt.want {
+				t.Errorf("Check() for %s format with %v = %v, want %v", tt.format, tt.value, got, tt.want)
+			}
+		})
+	}
+
+	if !NewConditions().Check(map[string]any{"id": "123e4567-e89b-12d3-a456-426614174000"}, map[string]any{"{{id}}": map[string]any{"$format": "uuid"}}) {
+		t.Errorf("expected built-in uuid format to accept a valid UUID")
+	}
+}
@@ -0,0 +1,283 @@
+package conditions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// earthRadius is the mean Earth radius in meters used for haversine distances.
+const earthRadius = 6371008.8
+
+// geoPoint is a WGS 84 coordinate in degrees.
+type geoPoint struct {
+	lon, lat float64
+}
+
+// toGeoPoint converts [lon, lat] pairs, maps with lat and lon (or lng, latitude, longitude) keys,
+// and structs with Lat and Lon (or Lng, Latitude, Longitude) fields to a point.
+func toGeoPoint(value any) (geoPoint, error) {
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Pointer {
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		if val.Len() < 2 {
+			return geoPoint{}, fmt.Errorf("expected [lon, lat] pair, got %d elements", val.Len())
+		}
+		lon, okLon := toFloat64(val.Index(0).Interface())
+		lat, okLat := toFloat64(val.Index(1).Interface())
+		if !okLon || !okLat {
+			return geoPoint{}, fmt.Errorf("expected numeric [lon, lat] pair, got %v", value)
+		}
+		return newGeoPoint(lon, lat)
+	case reflect.Map, reflect.Struct:
+		lat, okLat := geoCoordinate(val, "lat", "latitude")
+		lon, okLon := geoCoordinate(val, "lon", "lng", "longitude")
+		if !okLat || !okLon {
+			return geoPoint{}, fmt.Errorf("expected point with latitude and longitude, got %T", value)
+		}
+		return newGeoPoint(lon, lat)
+	default:
+		return geoPoint{}, fmt.Errorf("expected point for instance value, got %T", value)
+	}
+}
+
+func newGeoPoint(lon, lat float64) (geoPoint, error) {
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return geoPoint{}, fmt.Errorf("coordinates out of range: lon %v, lat %v", lon, lat)
+	}
+	return geoPoint{lon: lon, lat: lat}, nil
+}
+
+// geoCoordinate looks up the first of the names as a case-insensitive map key or exported struct field.
+func geoCoordinate(val reflect.Value, names ...string) (float64, bool) {
+	for _, name := range names {
+		var field reflect.Value
+		if val.Kind() == reflect.Map {
+			for _, key := range val.MapKeys() {
+				if key.Kind() == reflect.String && strings.EqualFold(key.String(), name) {
+					field = val.MapIndex(key)
+					break
+				}
+			}
+		} else {
+			field = exportedField(val, name)
+		}
+		if field.IsValid() && field.CanInterface() {
+			return toFloat64(field.Interface())
+		}
+	}
+	return 0, false
+}
+
+// exportedField returns the exported field of a struct whose name matches case-insensitively.
+// Unexported fields cannot be read through reflection and are skipped.
+func exportedField(val reflect.Value, name string) reflect.Value {
+	for _, field := range reflect.VisibleFields(val.Type()) {
+		if !field.IsExported() || !strings.EqualFold(field.Name, name) {
+			continue
+		}
+		if value, err := val.FieldByIndexErr(field.Index); err == nil {
+			return value
+		}
+	}
+	return reflect.Value{}
+}
+
+// haversine returns the great-circle distance between two points in meters.
+func haversine(a, b geoPoint) float64 {
+	lat1, lat2 := a.lat*math.Pi/180, b.lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.lon - a.lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// geoPolygon is an outer ring with optional holes, each ring a list of points.
+type geoPolygon [][]geoPoint
+
+func (p geoPolygon) contains(point geoPoint) bool {
+	if len(p) == 0 || !ringContains(p[0], point) {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if ringContains(hole, point) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains is the even-odd ray casting test on planar longitude and latitude.
+func ringContains(ring []geoPoint, point geoPoint) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.lat > point.lat) != (b.lat > point.lat) &&
+			point.lon < (b.lon-a.lon)*(point.lat-a.lat)/(b.lat-a.lat)+a.lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// toGeoPolygons converts GeoJSON Polygon and MultiPolygon geometries (as maps or JSON strings),
+// GeoJSON Features wrapping them, lists of rings and single rings to polygons.
+func toGeoPolygons(value any) ([]geoPolygon, error) {
+	if str, ok := value.(string); ok {
+		var decoded any
+		if err := json.Unmarshal([]byte(str), &decoded); err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+		}
+		value = decoded
+	}
+
+	if geometry, ok := value.(map[string]any); ok {
+		switch geometry["type"] {
+		case "Feature":
+			return toGeoPolygons(geometry["geometry"])
+		case "Polygon":
+			polygon, err := toGeoPolygon(geometry["coordinates"])
+			if err != nil {
+				return nil, err
+			}
+			return []geoPolygon{polygon}, nil
+		case "MultiPolygon":
+			val := reflect.ValueOf(geometry["coordinates"])
+			if val.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("expected MultiPolygon coordinates to be a list")
+			}
+			polygons := make([]geoPolygon, val.Len())
+			for i := range polygons {
+				var err error
+				if polygons[i], err = toGeoPolygon(val.Index(i).Interface()); err != nil {
+					return nil, err
+				}
+			}
+			return polygons, nil
+		default:
+			return nil, fmt.Errorf("unsupported GeoJSON type %v", geometry["type"])
+		}
+	}
+
+	polygon, err := toGeoPolygon(value)
+	if err != nil {
+		return nil, err
+	}
+	return []geoPolygon{polygon}, nil
+}
+
+// toGeoPolygon converts a list of rings, or a single ring of [lon, lat] points, to a polygon.
+func toGeoPolygon(value any) (geoPolygon, error) {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.Slice || val.Len() == 0 {
+		return nil, fmt.Errorf("expected polygon to be a non-empty list, got %T", value)
+	}
+
+	// A ring is a list of points, whose first element is itself a list of numbers.
+	first := reflect.ValueOf(val.Index(0).Interface())
+	if first.Kind() == reflect.Slice && first.Len() > 0 {
+		if _, isNumber := toFloat64(first.Index(0).Interface()); isNumber {
+			ring, err := toGeoRing(val)
+			if err != nil {
+				return nil, err
+			}
+			return geoPolygon{ring}, nil
+		}
+	}
+
+	polygon := make(geoPolygon, val.Len())
+	for i := range polygon {
+		var err error
+		if polygon[i], err = toGeoRing(reflect.ValueOf(val.Index(i).Interface())); err != nil {
+			return nil, err
+		}
+	}
+	return polygon, nil
+}
+
+func toGeoRing(val reflect.Value) ([]geoPoint, error) {
+	if val.Kind() != reflect.Slice || val.Len() < 3 {
+		return nil, fmt.Errorf("expected polygon ring with at least three points")
+	}
+	ring := make([]geoPoint, val.Len())
+	for i := range ring {
+		var err error
+		if ring[i], err = toGeoPoint(val.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return ring, nil
+}
+
+// checkGeoOperator evaluates the geospatial operators against a point fact.
+func (c *Conditions) checkGeoOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	point, err := toGeoPoint(fact)
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case GEOWITHIN:
+		polygons, err := toGeoPolygons(conditionValue)
+		if err != nil {
+			return false, err
+		}
+		for _, polygon := range polygons {
+			if polygon.contains(point) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case GEONEAR:
+		spec, ok := conditionValue.(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("expected object with point and maxDistance for %s operator, got %T", operator, conditionValue)
+		}
+		center, err := toGeoPoint(spec["point"])
+		if err != nil {
+			return false, err
+		}
+		maxDistance, ok := toFloat64(spec["maxDistance"])
+		if !ok {
+			return false, fmt.Errorf("expected numeric maxDistance in meters for %s operator", operator)
+		}
+		minDistance := 0.0
+		if value, exists := spec["minDistance"]; exists {
+			if minDistance, ok = toFloat64(value); !ok {
+				return false, fmt.Errorf("expected numeric minDistance in meters for %s operator", operator)
+			}
+		}
+		distance := haversine(point, center)
+		return distance >= minDistance && distance <= maxDistance, nil
+	case GEOBOX:
+		val := reflect.ValueOf(conditionValue)
+		if val.Kind() != reflect.Slice || val.Len() != 2 {
+			return false, fmt.Errorf("expected [[minLon, minLat], [maxLon, maxLat]] for %s operator", operator)
+		}
+		southWest, err := toGeoPoint(val.Index(0).Interface())
+		if err != nil {
+			return false, err
+		}
+		northEast, err := toGeoPoint(val.Index(1).Interface())
+		if err != nil {
+			return false, err
+		}
+		if point.lat < southWest.lat || point.lat > northEast.lat {
+			return false, nil
+		}
+		if southWest.lon <= northEast.lon {
+			return point.lon >= southWest.lon && point.lon <= northEast.lon, nil
+		}
+		// The box crosses the antimeridian.
+		return point.lon >= southWest.lon || point.lon <= northEast.lon, nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
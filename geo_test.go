@@ -0,0 +1,119 @@
+package conditions
+
+import (
+	"testing"
+)
+
+type geoLocation struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type privateGeoLocation struct {
+	lat float64
+	lon float64
+}
+
+func TestGeoOperators(t *testing.T) {
+	cond := NewConditions()
+
+	// A square around central Berlin with a hole around the Tiergarten.
+	polygon := map[string]any{
+		"type": "Polygon",
+		"coordinates": []any{
+			[]any{[]any{13.30, 52.48}, []any{13.48, 52.48}, []any{13.48, 52.56}, []any{13.30, 52.56}, []any{13.30, 52.48}},
+			[]any{[]any{13.33, 52.51}, []any{13.37, 52.51}, []any{13.37, 52.52}, []any{13.33, 52.52}, []any{13.33, 52.51}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $geoWithin operator with GeoJSON polygon",
+			condition: map[string]any{"{{customer.location}}": map[string]any{"$geoWithin": polygon}},
+			instance:  map[string]any{"customer": map[string]any{"location": []float64{13.405, 52.52}}},
+			want:      true,
+		},
+		{
+			name:      "Test $geoWithin operator inside hole",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoWithin": polygon}},
+			instance:  map[string]any{"location": map[string]any{"lat": 52.515, "lon": 13.35}},
+			want:      false,
+		},
+		{
+			name:      "Test $geoWithin operator with GeoJSON string and struct fact",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoWithin": `{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]], [[[13, 52], [14, 52], [14, 53], [13, 53], [13, 52]]]]}`}},
+			instance:  map[string]any{"location": geoLocation{Latitude: 52.52, Longitude: 13.405}},
+			want:      true,
+		},
+		{
+			name:      "Test $geoWithin operator with unexported struct fields",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoWithin": [][]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}},
+			instance:  map[string]any{"location": privateGeoLocation{lat: 5, lon: 5}},
+			want:      false,
+		},
+		{
+			name:      "Test $geoWithin operator with plain ring",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoWithin": [][]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}},
+			instance:  map[string]any{"location": map[string]any{"lat": 11.0, "lng": 5.0}},
+			want:      false,
+		},
+		{
+			name: "Test $geoNear operator within radius",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoNear": map[string]any{
+				"point":       []float64{13.3777, 52.5163}, // Brandenburg Gate
+				"maxDistance": 2000,
+			}}},
+			instance: map[string]any{"location": []float64{13.4050, 52.5200}}, // About 1.9 km away
+			want:     true,
+		},
+		{
+			name: "Test $geoNear operator outside radius",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoNear": map[string]any{
+				"point":       []float64{13.3777, 52.5163},
+				"maxDistance": 1500,
+			}}},
+			instance: map[string]any{"location": []float64{13.4050, 52.5200}},
+			want:     false,
+		},
+		{
+			name:      "Test $geoBox operator true",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoBox": [][]float64{{13.0, 52.3}, {13.8, 52.7}}}},
+			instance:  map[string]any{"location": &geoLocation{Latitude: 52.52, Longitude: 13.405}},
+			want:      true,
+		},
+		{
+			name:      "Test $geoBox operator across antimeridian",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoBox": [][]float64{{170, -20}, {-170, -10}}}},
+			instance:  map[string]any{"location": []float64{-178.4, -18.1}},
+			want:      true,
+		},
+		{
+			name:      "Test $geoBox operator with invalid latitude",
+			condition: map[string]any{"{{location}}": map[string]any{"$geoBox": [][]float64{{13.0, 52.3}, {13.8, 52.7}}}},
+			instance:  map[string]any{"location": []float64{13.4, 152.5}},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeoOperatorsRejectUnexportedFields(t *testing.T) {
+	cond := NewConditions()
+	condition := map[string]any{"{{location}}": map[string]any{"$geoNear": map[string]any{"point": []float64{5, 5}, "maxDistance": 1000}}}
+
+	if got, err := cond.Evaluate(map[string]any{"location": privateGeoLocation{lat: 5, lon: 5}}, condition); got || err == nil {
+		t.Errorf("Evaluate() = %v, %v, want false and an error", got, err)
+	}
+}
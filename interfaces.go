@@ -1,7 +1,54 @@
 package conditions
 
-type Conditions struct{}
+import "time"
 
-func NewConditions() *Conditions {
-	return &Conditions{}
+type Conditions struct {
+	regexCache          *regexCache // Compiled $re patterns shared across evaluations
+	maxPatternLength    int         // Maximum accepted $re pattern length, 0 means unlimited
+	text                textOptions // Normalization, case folding and collation of strings
+	now                 func() time.Time
+	location            *time.Location // Time zone for calendar calculations
+	locations           *locationCache // Time zones named in conditions
+	holidays            map[string]HolidayCalendar
+	units               *UnitRegistry // Units known to the quantity operators
+	prefixSets          *prefixSetCache
+	formats             map[string]FormatValidator // Custom formats for $format
+	schemas             *schemaCache               // Compiled $schema operands
+	coercion            CoercionPolicy
+	truthiness          Truthiness
+	legacyListOperators bool // Original $every and $noone semantics for list operands
+}
+
+// Option configures a Conditions instance.
+type Option func(*Conditions)
+
+func NewConditions(opts ...Option) *Conditions {
+	c := &Conditions{
+		regexCache: newRegexCache(defaultRegexCacheSize),
+		now:        time.Now,
+		location:   time.Local,
+		locations:  newLocationCache(),
+		units:      NewUnitRegistry(),
+		prefixSets: newPrefixSetCache(),
+		schemas:    newSchemaCache(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithRegexCacheSize sets how many compiled $re patterns are kept in the LRU cache.
+// A size of 0 or less disables caching.
+func WithRegexCacheSize(size int) Option {
+	return func(c *Conditions) {
+		c.regexCache = newRegexCache(size)
+	}
+}
+
+// WithMaxPatternLength limits the length of $re, $like, $ilike and $glob patterns, 0 means unlimited.
+func WithMaxPatternLength(length int) Option {
+	return func(c *Conditions) {
+		c.maxPatternLength = length
+	}
 }
@@ -0,0 +1,116 @@
+package conditions
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// sortedMapKeys returns the keys of a map in a deterministic order: numerically for numbers and by
+// their string form otherwise, so that map entries are visited and reported the same way every time.
+func sortedMapKeys(val reflect.Value) []reflect.Value {
+	keys := val.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		if result, ok := compareNumbers(keys[i].Interface(), keys[j].Interface()); ok {
+			return result < 0
+		}
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// checkMapOperator handles the map operators:
+//
+//   - $hasKey: the map has the key
+//   - $hasAllKeys, $hasAnyKey: the map has all or any of the listed keys
+//   - $hasValue: some value equals the operand
+//   - $anyValue, $allValues: some or every value equals the operand, or satisfies the operand sub-condition
+//
+// Keys and values are compared as $eq compares them. A value the sub-condition cannot be evaluated for
+// does not match; the error returned is the one of the value that decided the result, or the first one.
+func (c *Conditions) checkMapOperator(operator CommonOperatorsEnum, key string, fact any, conditionValue any, instance any) (bool, error) {
+	val := reflect.ValueOf(fact)
+	if val.Kind() == reflect.Pointer {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Map {
+		return false, fmt.Errorf("expected a map for instance value under key %s, got %T", key, fact)
+	}
+
+	switch operator {
+	case HASKEY:
+		return c.hasMapKey(val, conditionValue), nil
+	case HASALLKEYS, HASANYKEY:
+		wanted, ok := listElements(conditionValue)
+		if !ok {
+			return false, fmt.Errorf("expected list of keys for %s operator, got %T", operator, conditionValue)
+		}
+		for _, wantedKey := range wanted {
+			found := c.hasMapKey(val, wantedKey)
+			if operator == HASANYKEY && found {
+				return true, nil
+			}
+			if operator == HASALLKEYS && !found {
+				return false, nil
+			}
+		}
+		return operator == HASALLKEYS, nil
+	case HASVALUE, ANYVALUE, ALLVALUES:
+		condition, isCondition := conditionValue.(map[string]any)
+		if operator == HASVALUE {
+			isCondition = false
+		}
+		var reported error // The first error of a value that did not match
+		for _, mapKey := range sortedMapKeys(val) {
+			value := val.MapIndex(mapKey).Interface()
+
+			var matched bool
+			var err error
+			if isCondition {
+				matched, err = c.matchElement(fmt.Sprintf("%s.%v", key, mapKey.Interface()), value, condition, instance)
+				if reported == nil {
+					reported = err
+				}
+			} else {
+				matched = c.valuesEqual(value, conditionValue)
+			}
+
+			if operator == ALLVALUES && !matched {
+				return false, err
+			}
+			if operator != ALLVALUES && matched {
+				return true, err
+			}
+		}
+		return operator == ALLVALUES, reported
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
+
+// hasMapKey reports whether the map has the key. Without coercion this is a lookup, so the key must have
+// the map's key type or its underlying type; with coercion keys are compared as $eq compares them, which takes a scan.
+func (c *Conditions) hasMapKey(val reflect.Value, wanted any) bool {
+	if c.coercion == CoercionNone {
+		key, keyType := reflect.ValueOf(wanted), val.Type().Key()
+		if !key.IsValid() || !key.Type().Comparable() {
+			return false
+		}
+		if !key.Type().AssignableTo(keyType) {
+			// Named key types like `type Role string` take operands of their underlying type. Other
+			// conversions, like an int to a string, would change the key instead of its type.
+			if key.Kind() != keyType.Kind() || !key.Type().ConvertibleTo(keyType) {
+				return false
+			}
+			key = key.Convert(keyType)
+		}
+		return val.MapIndex(key).IsValid()
+	}
+
+	for _, mapKey := range val.MapKeys() {
+		if c.valuesEqual(mapKey.Interface(), wanted) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package conditions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapOperators(t *testing.T) {
+	cond := NewConditions()
+	instance := map[string]any{
+		"headers": map[string]any{"Content-Type": "application/json", "X-Request-Id": "abc"},
+		"codes":   map[int]string{200: "ok", 404: "missing"},
+		"stock": map[string]any{
+			"berlin": map[string]any{"qty": 12, "reserved": 2},
+			"paris":  map[string]any{"qty": 3, "reserved": 0},
+		},
+		"flags": map[string]bool{"beta": true, "dark": true},
+		"list":  []string{"a"},
+	}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      bool
+	}{
+		{
+			name:      "Test $hasKey operator true",
+			condition: map[string]any{"{{headers}}": map[string]any{"$hasKey": "X-Request-Id"}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasKey operator does not match values",
+			condition: map[string]any{"{{headers}}": map[string]any{"$hasKey": "abc"}},
+			want:      false,
+		},
+		{
+			name:      "Test $hasKey operator with int keys",
+			condition: map[string]any{"{{codes}}": map[string]any{"$hasKey": 404}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasAllKeys operator false",
+			condition: map[string]any{"{{headers}}": map[string]any{"$hasAllKeys": []string{"Content-Type", "Authorization"}}},
+			want:      false,
+		},
+		{
+			name:      "Test $hasAnyKey operator true",
+			condition: map[string]any{"{{headers}}": map[string]any{"$hasAnyKey": []string{"Authorization", "Content-Type"}}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasValue operator true",
+			condition: map[string]any{"{{codes}}": map[string]any{"$hasValue": "missing"}},
+			want:      true,
+		},
+		{
+			name:      "Test $anyValue operator with sub-condition",
+			condition: map[string]any{"{{stock}}": map[string]any{"$anyValue": map[string]any{"{{qty}}": map[string]any{"$lt": 5}}}},
+			want:      true,
+		},
+		{
+			name: "Test $allValues operator with sub-condition",
+			condition: map[string]any{"{{stock}}": map[string]any{"$allValues": map[string]any{
+				"{{qty}}":      map[string]any{"$gt": 0},
+				"{{reserved}}": map[string]any{"$gt": 0},
+			}}},
+			want: false,
+		},
+		{
+			name:      "Test $allValues operator with value",
+			condition: map[string]any{"{{flags}}": map[string]any{"$allValues": true}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasKey operator with list fact",
+			condition: map[string]any{"{{list}}": map[string]any{"$hasKey": "a"}},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasKeyCoercion(t *testing.T) {
+	type Role string
+	instance := map[string]any{
+		"codes":  map[int]string{404: "Not Found"},
+		"tags":   map[any]bool{"new": true},
+		"roles":  map[Role]bool{"admin": true},
+		"grades": map[string]int{"A": 1},
+	}
+
+	tests := []struct {
+		name      string
+		cond      *Conditions
+		condition map[string]any
+		want      bool
+	}{
+		{
+			name:      "Test $hasKey with numeric string without coercion",
+			cond:      NewConditions(),
+			condition: map[string]any{"{{codes}}": map[string]any{"$hasKey": "404"}},
+			want:      false,
+		},
+		{
+			name:      "Test $hasKey with numeric string and lenient coercion",
+			cond:      NewConditions(WithCoercion(CoercionLenient)),
+			condition: map[string]any{"{{codes}}": map[string]any{"$hasKey": "404"}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasKey with interface keys",
+			cond:      NewConditions(),
+			condition: map[string]any{"{{tags}}": map[string]any{"$hasKey": "new"}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasKey with named key type",
+			cond:      NewConditions(),
+			condition: map[string]any{"{{roles}}": map[string]any{"$hasKey": "admin"}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasAnyKey with named key type",
+			cond:      NewConditions(),
+			condition: map[string]any{"{{roles}}": map[string]any{"$hasAnyKey": []string{"editor", "admin"}}},
+			want:      true,
+		},
+		{
+			name:      "Test $hasKey with number for string keys",
+			cond:      NewConditions(),
+			condition: map[string]any{"{{grades}}": map[string]any{"$hasKey": 65}},
+			want:      false,
+		},
+		{
+			name:      "Test $hasKey with uncomparable operand",
+			cond:      NewConditions(),
+			condition: map[string]any{"{{tags}}": map[string]any{"$hasKey": []string{"new"}}},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapOperatorErrorsFollowKeyOrder(t *testing.T) {
+	cond := NewConditions()
+	instance := map[string]any{
+		"stock": map[string]any{"paris": "n/a", "berlin": "n/a", "oslo": "n/a"},
+	}
+	condition := map[string]any{"{{stock}}": map[string]any{"$anyValue": map[string]any{"$size": 1}}}
+
+	for i := 0; i < 20; i++ {
+		_, err := cond.Evaluate(instance, condition)
+		if err == nil || !strings.Contains(err.Error(), "under key {{stock}}.berlin") {
+			t.Fatalf("Evaluate() error = %v, want error for stock.berlin", err)
+		}
+	}
+}
+
+func TestMapOperatorsSkipValuesThatFail(t *testing.T) {
+	cond := NewConditions()
+	// Berlin reports no count, so its value cannot be compared.
+	instance := map[string]any{
+		"stock": map[string]any{"paris": 12, "berlin": "n/a", "oslo": 3},
+	}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "Test $anyValue with a value that fails",
+			condition: map[string]any{"{{stock}}": map[string]any{"$anyValue": map[string]any{"$gt": 10}}},
+			want:      true,
+		},
+		{
+			name:      "Test $anyValue without a matching value",
+			condition: map[string]any{"{{stock}}": map[string]any{"$anyValue": map[string]any{"$gt": 100}}},
+			want:      false,
+			wantErr:   true,
+		},
+		{
+			name:      "Test $allValues with a value that fails",
+			condition: map[string]any{"{{stock}}": map[string]any{"$allValues": map[string]any{"$gt": 0}}},
+			want:      false,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+			got, err := cond.Evaluate(instance, tt.condition)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("Evaluate() for %s = %v, %v, want %v and error %v", tt.name, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
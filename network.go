@@ -0,0 +1,292 @@
+package conditions
+
+import (
+	"container/list"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PrefixSet is a binary trie of IP prefixes. It matches addresses against large CIDR lists in time
+// proportional to the address length instead of the number of prefixes, and can be passed directly
+// as the $cidr operand to skip parsing the list on every evaluation.
+type PrefixSet struct {
+	root [2]*prefixTrieNode // IPv4 and IPv6 roots
+}
+
+type prefixTrieNode struct {
+	children [2]*prefixTrieNode
+	terminal bool
+}
+
+// NewPrefixSet builds a set from CIDR strings like "10.0.0.0/8" or plain addresses.
+func NewPrefixSet(prefixes ...string) (*PrefixSet, error) {
+	parsed := make([]netip.Prefix, len(prefixes))
+	for i, prefix := range prefixes {
+		var err error
+		if parsed[i], err = toPrefix(prefix); err != nil {
+			return nil, err
+		}
+	}
+	return newPrefixSet(parsed), nil
+}
+
+func newPrefixSet(prefixes []netip.Prefix) *PrefixSet {
+	set := &PrefixSet{}
+	for _, prefix := range prefixes {
+		set.insert(prefix)
+	}
+	return set
+}
+
+func familyIndex(addr netip.Addr) int {
+	if addr.Is4() {
+		return 0
+	}
+	return 1
+}
+
+func (s *PrefixSet) insert(prefix netip.Prefix) {
+	prefix = prefix.Masked()
+	family := familyIndex(prefix.Addr())
+	if s.root[family] == nil {
+		s.root[family] = &prefixTrieNode{}
+	}
+
+	node := s.root[family]
+	bytes := prefix.Addr().AsSlice()
+	for i := 0; i < prefix.Bits(); i++ {
+		bit := bytes[i/8] >> (7 - i%8) & 1
+		if node.children[bit] == nil {
+			node.children[bit] = &prefixTrieNode{}
+		}
+		node = node.children[bit]
+	}
+	node.terminal = true
+}
+
+// Contains reports whether any prefix in the set contains the address.
+func (s *PrefixSet) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	node := s.root[familyIndex(addr)]
+	bytes := addr.AsSlice()
+	for i := 0; node != nil; i++ {
+		if node.terminal {
+			return true
+		}
+		if i == len(bytes)*8 {
+			return false
+		}
+		node = node.children[bytes[i/8]>>(7-i%8)&1]
+	}
+	return false
+}
+
+// prefixSetCache is a bounded LRU cache of sets built for $cidr list operands, safe for concurrent use.
+// Lists are keyed by their content, so that a rule evaluated repeatedly parses its list only once and a
+// list changed in place is parsed again.
+type prefixSetCache struct {
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type prefixSetCacheEntry struct {
+	key string
+	set *PrefixSet
+}
+
+const maxCachedPrefixSets = 64
+
+func newPrefixSetCache() *prefixSetCache {
+	return &prefixSetCache{
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// prefixListKey returns the cache key of a list operand. Strings are quoted, so that a string
+// holding several lines never has the key of a list of those lines.
+func prefixListKey(val reflect.Value) string {
+	var key strings.Builder
+	for i := 0; i < val.Len(); i++ {
+		switch element := val.Index(i).Interface().(type) {
+		case string:
+			key.WriteString(strconv.Quote(element))
+		default:
+			fmt.Fprintf(&key, "%T(%v)", element, element)
+		}
+		key.WriteByte('\n')
+	}
+	return key.String()
+}
+
+// get returns the set for a slice of prefixes, building it on first use.
+func (pc *prefixSetCache) get(val reflect.Value) (*PrefixSet, error) {
+	key := prefixListKey(val)
+
+	pc.mu.Lock()
+	if elem, ok := pc.items[key]; ok {
+		pc.order.MoveToFront(elem)
+		pc.mu.Unlock()
+		return elem.Value.(*prefixSetCacheEntry).set, nil
+	}
+	pc.mu.Unlock()
+
+	prefixes := make([]netip.Prefix, val.Len())
+	for i := range prefixes {
+		var err error
+		if prefixes[i], err = toPrefix(val.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	set := newPrefixSet(prefixes)
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if _, ok := pc.items[key]; !ok {
+		pc.items[key] = pc.order.PushFront(&prefixSetCacheEntry{key: key, set: set})
+		for pc.order.Len() > maxCachedPrefixSets {
+			oldest := pc.order.Back()
+			pc.order.Remove(oldest)
+			delete(pc.items, oldest.Value.(*prefixSetCacheEntry).key)
+		}
+	}
+	return set, nil
+}
+
+func (pc *prefixSetCache) len() int {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return pc.order.Len()
+}
+
+// toAddr converts a netip.Addr, a net.IP-like byte slice or an address string to a netip.Addr.
+// IPv4-mapped IPv6 addresses are unmapped so they match IPv4 prefixes.
+func toAddr(value any) (netip.Addr, error) {
+	switch v := value.(type) {
+	case netip.Addr:
+		return v.Unmap(), nil
+	case string:
+		addr, err := netip.ParseAddr(strings.TrimSpace(v))
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		return addr.Unmap(), nil
+	case []byte:
+		addr, ok := netip.AddrFromSlice(v)
+		if !ok {
+			return netip.Addr{}, fmt.Errorf("invalid IP address of length %d", len(v))
+		}
+		return addr.Unmap(), nil
+	}
+
+	// Named byte slices like net.IP.
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		return toAddr(rv.Bytes())
+	}
+	return netip.Addr{}, fmt.Errorf("expected IP address for instance value, got %T", value)
+}
+
+// toPrefix converts a netip.Prefix or a CIDR string to a prefix. A plain address is a single host prefix.
+func toPrefix(value any) (netip.Prefix, error) {
+	switch v := value.(type) {
+	case netip.Prefix:
+		return v, nil
+	case string:
+		if strings.Contains(v, "/") {
+			return netip.ParsePrefix(strings.TrimSpace(v))
+		}
+	}
+
+	addr, err := toAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("expected CIDR prefix, got %v", value)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// privatePrefixes are the RFC 1918, RFC 4193 unique local, loopback and link-local ranges.
+var privatePrefixes = newPrefixSet([]netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("fe80::/10"),
+})
+
+// checkNetworkOperator evaluates the IP address and CIDR operators.
+func (c *Conditions) checkNetworkOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	addr, err := toAddr(fact)
+
+	switch operator {
+	case IPV4, IPV6, IPPRIVATE:
+		expected, ok := conditionValue.(bool)
+		if !ok {
+			return false, fmt.Errorf("expected bool for %s operator, got %T", operator, conditionValue)
+		}
+		if err != nil {
+			// Values that are not IP addresses are neither IPv4, IPv6 nor private.
+			return !expected, nil
+		}
+		switch operator {
+		case IPV4:
+			return addr.Is4() == expected, nil
+		case IPV6:
+			return addr.Is6() == expected, nil
+		default:
+			return privatePrefixes.Contains(addr) == expected, nil
+		}
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case CIDR:
+		if set, ok := conditionValue.(*PrefixSet); ok {
+			return set.Contains(addr), nil
+		}
+
+		val := reflect.ValueOf(conditionValue)
+		if val.Kind() != reflect.Slice || val.Type().Elem().Kind() == reflect.Uint8 {
+			prefix, err := toPrefix(conditionValue)
+			if err != nil {
+				return false, err
+			}
+			return prefix.Contains(addr), nil
+		}
+
+		set, err := c.prefixSets.get(val)
+		if err != nil {
+			return false, err
+		}
+		return set.Contains(addr), nil
+	case IPRANGE:
+		val := reflect.ValueOf(conditionValue)
+		if val.Kind() != reflect.Slice || val.Len() != 2 {
+			return false, fmt.Errorf("expected condition to be a slice with exactly two elements")
+		}
+		from, err := toAddr(val.Index(0).Interface())
+		if err != nil {
+			return false, err
+		}
+		to, err := toAddr(val.Index(1).Interface())
+		if err != nil {
+			return false, err
+		}
+		if from.BitLen() != addr.BitLen() || to.BitLen() != addr.BitLen() {
+			return false, nil
+		}
+		return addr.Compare(from) >= 0 && addr.Compare(to) <= 0, nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
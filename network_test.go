@@ -0,0 +1,160 @@
+package conditions
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestNetworkOperators(t *testing.T) {
+	cond := NewConditions()
+
+	blocklist, err := NewPrefixSet("203.0.113.0/24", "198.51.100.7", "2001:db8:dead::/48")
+	if err != nil {
+		t.Fatalf("NewPrefixSet() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $cidr operator with single prefix",
+			condition: map[string]any{"{{client.ip}}": map[string]any{"$cidr": "10.0.0.0/8"}},
+			instance:  map[string]any{"client": map[string]any{"ip": "10.20.30.40"}},
+			want:      true,
+		},
+		{
+			name:      "Test $cidr operator with prefix list",
+			condition: map[string]any{"{{ip}}": map[string]any{"$cidr": []string{"10.0.0.0/8", "192.168.0.0/16"}}},
+			instance:  map[string]any{"ip": netip.MustParseAddr("192.168.1.1")},
+			want:      true,
+		},
+		{
+			name:      "Test $cidr operator with prefix list false",
+			condition: map[string]any{"{{ip}}": map[string]any{"$cidr": []string{"10.0.0.0/8", "192.168.0.0/16"}}},
+			instance:  map[string]any{"ip": "172.16.0.1"},
+			want:      false,
+		},
+		{
+			name:      "Test $cidr operator with IPv4-mapped address",
+			condition: map[string]any{"{{ip}}": map[string]any{"$cidr": "192.168.0.0/16"}},
+			instance:  map[string]any{"ip": "::ffff:192.168.3.4"},
+			want:      true,
+		},
+		{
+			name:      "Test $cidr operator with net.IP fact",
+			condition: map[string]any{"{{ip}}": map[string]any{"$cidr": "192.168.0.0/16"}},
+			instance:  map[string]any{"ip": net.ParseIP("192.168.3.4")},
+			want:      true,
+		},
+		{
+			name: "Test $cidr operator with prefix set in $not",
+			condition: map[string]any{"$not": []map[string]any{
+				{"{{ip}}": map[string]any{"$cidr": blocklist}},
+			}},
+			instance: map[string]any{"ip": "2001:db8:beef::1"},
+			want:     true,
+		},
+		{
+			name:      "Test $cidr operator with prefix set host entry",
+			condition: map[string]any{"{{ip}}": map[string]any{"$cidr": blocklist}},
+			instance:  map[string]any{"ip": "198.51.100.7"},
+			want:      true,
+		},
+		{
+			name:      "Test $cidr operator with invalid fact",
+			condition: map[string]any{"{{ip}}": map[string]any{"$cidr": "10.0.0.0/8"}},
+			instance:  map[string]any{"ip": "not an ip"},
+			want:      false,
+		},
+		{
+			name:      "Test $ipv4 operator true",
+			condition: map[string]any{"{{ip}}": map[string]any{"$ipv4": true}},
+			instance:  map[string]any{"ip": "8.8.8.8"},
+			want:      true,
+		},
+		{
+			name:      "Test $ipv6 operator true",
+			condition: map[string]any{"{{ip}}": map[string]any{"$ipv6": true}},
+			instance:  map[string]any{"ip": "2001:4860:4860::8888"},
+			want:      true,
+		},
+		{
+			name:      "Test $ipPrivate operator true",
+			condition: map[string]any{"{{ip}}": map[string]any{"$ipPrivate": true}},
+			instance:  map[string]any{"ip": "fd12:3456:789a::1"},
+			want:      true,
+		},
+		{
+			name:      "Test $ipPrivate operator false for public address",
+			condition: map[string]any{"{{ip}}": map[string]any{"$ipPrivate": false}},
+			instance:  map[string]any{"ip": "8.8.4.4"},
+			want:      true,
+		},
+		{
+			name:      "Test $ipRange operator true",
+			condition: map[string]any{"{{ip}}": map[string]any{"$ipRange": []string{"10.0.0.10", "10.0.0.20"}}},
+			instance:  map[string]any{"ip": "10.0.0.15"},
+			want:      true,
+		},
+		{
+			name:      "Test $ipRange operator with other family",
+			condition: map[string]any{"{{ip}}": map[string]any{"$ipRange": []string{"10.0.0.10", "10.0.0.20"}}},
+			instance:  map[string]any{"ip": "::1"},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixListCache(t *testing.T) {
+	cond := NewConditions()
+	allowlist := []string{"10.0.0.0/8", "192.168.0.0/16"}
+	condition := map[string]any{"{{ip}}": map[string]any{"$cidr": allowlist}}
+
+	for _, ip := range []string{"10.1.2.3", "192.168.1.1", "8.8.8.8"} {
+		cond.Check(map[string]any{"ip": ip}, condition)
+	}
+	if got := cond.prefixSets.len(); got != 1 {
+		t.Fatalf("cache size = %d, want 1", got)
+	}
+
+	// A list with the same content, like one decoded from JSON again, reuses the set.
+	decoded := []any{"10.0.0.0/8", "192.168.0.0/16"}
+	if !cond.Check(map[string]any{"ip": "10.1.2.3"}, map[string]any{"{{ip}}": map[string]any{"$cidr": decoded}}) {
+		t.Fatalf("Check() = false, want true")
+	}
+	if got := cond.prefixSets.len(); got != 1 {
+		t.Fatalf("cache size = %d, want 1", got)
+	}
+
+	// A list changed in place gets a new set.
+	allowlist[1] = "8.8.8.0/24"
+	if !cond.Check(map[string]any{"ip": "8.8.8.8"}, condition) {
+		t.Errorf("Check() after changing the list = false, want true")
+	}
+	if cond.Check(map[string]any{"ip": "192.168.1.1"}, condition) {
+		t.Errorf("Check() for a removed prefix = true, want false")
+	}
+
+	for i := 0; i < maxCachedPrefixSets+10; i++ {
+		prefixes := []string{fmt.Sprintf("10.%d.0.0/16", i)}
+		if !cond.Check(map[string]any{"ip": fmt.Sprintf("10.%d.2.3", i)}, map[string]any{"{{ip}}": map[string]any{"$cidr": prefixes}}) {
+			t.Fatalf("Check() = false, want true")
+		}
+	}
+	if got := cond.prefixSets.len(); got != maxCachedPrefixSets {
+		t.Errorf("cache size = %d, want %d", got, maxCachedPrefixSets)
+	}
+}
@@ -0,0 +1,279 @@
+package conditions
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// Decimal is implemented by decimal types, such as shopspring/decimal's Decimal, so that they are
+// compared exactly with each other and with other numbers.
+type Decimal interface {
+	Rat() *big.Rat
+}
+
+// number is a numeric value in a form that can be compared without losing precision.
+type number struct {
+	rat   *big.Rat // Exact value, nil for NaN and infinities
+	float float64  // Value of NaN and infinities
+	prec  uint     // Binary precision of floating-point values, 0 for exact values
+}
+
+// isArbitraryPrecision reports whether the value is a big number, json.Number or Decimal rather than a
+// built-in Go number.
+func isArbitraryPrecision(value any) bool {
+	switch value.(type) {
+	case *big.Int, *big.Rat, *big.Float, json.Number, Decimal:
+		return true
+	default:
+		return false
+	}
+}
+
+// toNumber converts Go numbers of any width, *big.Int, *big.Rat, *big.Float, json.Number and Decimal values.
+func toNumber(value any) (number, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return number{}, false
+		}
+		return number{rat: new(big.Rat).SetInt(v)}, true
+	case *big.Rat:
+		if v == nil {
+			return number{}, false
+		}
+		return number{rat: v}, true
+	case *big.Float:
+		if v == nil {
+			return number{}, false
+		}
+		if v.IsInf() {
+			f, _ := v.Float64()
+			return number{float: f, prec: v.Prec()}, true
+		}
+		r, _ := v.Rat(nil)
+		return number{rat: r, prec: v.Prec()}, true
+	case json.Number:
+		r, ok := new(big.Rat).SetString(string(v))
+		return number{rat: r}, ok
+	case Decimal:
+		r := v.Rat()
+		return number{rat: r}, r != nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{rat: new(big.Rat).SetInt64(rv.Int())}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{rat: new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint()))}, true
+	case reflect.Float32, reflect.Float64:
+		prec := uint(53)
+		if rv.Kind() == reflect.Float32 {
+			prec = 24
+		}
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return number{float: f, prec: prec}, true
+		}
+		return number{rat: new(big.Rat).SetFloat64(f), prec: prec}, true
+	default:
+		return number{}, false
+	}
+}
+
+// compareNumbers compares two numbers exactly. ok is false when either value is not a number or is NaN,
+// since NaN is unordered.
+//
+// Built-in integers of the same signedness and floats are compared directly. Otherwise the values are
+// compared as fractions, except that a decimal fraction compared with a float is first rounded to the
+// float's precision, so json.Number("0.1") equals float64(0.1).
+func compareNumbers(a, b any) (int, bool) {
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case isArbitraryPrecision(a) || isArbitraryPrecision(b):
+	case isIntKind(ra.Kind()) && isIntKind(rb.Kind()):
+		return cmp.Compare(ra.Int(), rb.Int()), true
+	case isUintKind(ra.Kind()) && isUintKind(rb.Kind()):
+		return cmp.Compare(ra.Uint(), rb.Uint()), true
+	case isFloatKind(ra.Kind()) && isFloatKind(rb.Kind()):
+		f1, f2 := ra.Float(), rb.Float()
+		return cmp.Compare(f1, f2), !math.IsNaN(f1) && !math.IsNaN(f2)
+	}
+
+	n1, ok := toNumber(a)
+	if !ok || n1.isNaN() {
+		return 0, false
+	}
+	n2, ok := toNumber(b)
+	if !ok || n2.isNaN() {
+		return 0, false
+	}
+	return n1.compare(n2), true
+}
+
+// compare orders two numbers that are not NaN. Infinities are greater or less than every finite number.
+func (n number) compare(other number) int {
+	switch {
+	case n.rat == nil && other.rat == nil:
+		return cmp.Compare(n.float, other.float)
+	case n.rat == nil:
+		return int(math.Copysign(1, n.float))
+	case other.rat == nil:
+		return -int(math.Copysign(1, other.float))
+	}
+	r1, r2 := n.rat, other.rat
+	if n.prec > 0 && other.prec == 0 && !r2.IsInt() {
+		r2 = roundRat(r2, n.prec)
+	} else if other.prec > 0 && n.prec == 0 && !r1.IsInt() {
+		r1 = roundRat(r1, other.prec)
+	}
+	return r1.Cmp(r2)
+}
+
+func (n number) toFloat64() float64 {
+	if n.rat == nil {
+		return n.float
+	}
+	f, _ := n.rat.Float64()
+	return f
+}
+
+func (n number) isNaN() bool {
+	return n.rat == nil && math.IsNaN(n.float)
+}
+
+// isNaN reports whether the value is a floating-point NaN.
+func isNaN(value any) bool {
+	n, ok := toNumber(value)
+	return ok && n.isNaN()
+}
+
+// roundRat rounds a fraction to the nearest floating-point value with the given binary precision.
+func roundRat(r *big.Rat, prec uint) *big.Rat {
+	rounded, _ := new(big.Float).SetPrec(prec).SetRat(r).Rat(nil)
+	return rounded
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// defaultRelTolerance is the relative tolerance of $approx when the operand gives none.
+const defaultRelTolerance = 1e-9
+
+// approxOperand is the operand of $approx: a number, or a map with the value and absolute ("abs") and
+// relative ("rel") tolerances.
+type approxOperand struct {
+	value float64
+	abs   float64
+	rel   float64
+}
+
+func parseApproxOperand(operand any) (approxOperand, error) {
+	if n, ok := toNumber(operand); ok {
+		return approxOperand{value: n.toFloat64(), rel: defaultRelTolerance}, nil
+	}
+	spec, ok := operand.(map[string]any)
+	if !ok {
+		return approxOperand{}, fmt.Errorf("expected number or map with value and tolerances for $approx operator, got %T", operand)
+	}
+
+	value, ok := toNumber(spec["value"])
+	if !ok {
+		return approxOperand{}, fmt.Errorf("expected number for $approx value, got %T", spec["value"])
+	}
+	approx := approxOperand{value: value.toFloat64(), rel: defaultRelTolerance}
+	if _, hasAbs := spec["abs"]; hasAbs {
+		approx.rel = 0
+	}
+	for name, target := range map[string]*float64{"abs": &approx.abs, "rel": &approx.rel} {
+		raw, exists := spec[name]
+		if !exists {
+			continue
+		}
+		tolerance, ok := toNumber(raw)
+		if !ok || tolerance.isNaN() || tolerance.toFloat64() < 0 {
+			return approxOperand{}, fmt.Errorf("expected non-negative number for $approx %s tolerance, got %v", name, raw)
+		}
+		*target = tolerance.toFloat64()
+	}
+	return approx, nil
+}
+
+// matches reports whether the number is within the absolute tolerance or the relative tolerance of the
+// larger magnitude, like Python's math.isclose. Infinities only match themselves and NaN matches nothing.
+func (a approxOperand) matches(f float64) bool {
+	if f == a.value {
+		return true
+	}
+	if math.IsInf(f, 0) || math.IsInf(a.value, 0) {
+		return false
+	}
+	diff := math.Abs(f - a.value)
+	return diff <= a.abs || diff <= a.rel*math.Max(math.Abs(f), math.Abs(a.value))
+}
+
+// modulo returns the remainder of the truncated division a / d, with the sign of a, like Go's % operator.
+func modulo(a, d *big.Rat) *big.Rat {
+	q := new(big.Rat).Quo(a, d)
+	truncated := new(big.Rat).SetInt(new(big.Int).Quo(q.Num(), q.Denom()))
+	return truncated.Mul(truncated, d).Sub(a, truncated)
+}
+
+// checkNumericOperator handles $mod, $approx, $finite and $nan.
+func (c *Conditions) checkNumericOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	num, ok := toNumber(fact)
+	if !ok || reflect.TypeOf(fact) == reflect.TypeOf(time.Duration(0)) {
+		return false, fmt.Errorf("expected numeric type for instance value, got %T", fact)
+	}
+
+	switch operator {
+	case MOD:
+		val := reflect.ValueOf(conditionValue)
+		if (val.Kind() != reflect.Slice && val.Kind() != reflect.Array) || val.Len() != 2 {
+			return false, fmt.Errorf("expected [divisor, remainder] for $mod operator, got %v", conditionValue)
+		}
+		divisor, okDivisor := toNumber(val.Index(0).Interface())
+		remainder, okRemainder := toNumber(val.Index(1).Interface())
+		if !okDivisor || !okRemainder || divisor.rat == nil || remainder.rat == nil {
+			return false, fmt.Errorf("expected finite numbers for $mod operator, got %v", conditionValue)
+		}
+		if divisor.rat.Sign() == 0 {
+			return false, fmt.Errorf("division by zero in $mod operator")
+		}
+		if num.rat == nil {
+			return false, nil
+		}
+		return modulo(num.rat, divisor.rat).Cmp(remainder.rat) == 0, nil
+	case APPROX:
+		approx, err := parseApproxOperand(conditionValue)
+		if err != nil {
+			return false, err
+		}
+		return approx.matches(num.toFloat64()), nil
+	case FINITE, NAN:
+		expected, ok := conditionValue.(bool)
+		if !ok {
+			return false, fmt.Errorf("expected bool for %s operator, got %T", operator, conditionValue)
+		}
+		if operator == FINITE {
+			return (num.rat != nil) == expected, nil
+		}
+		return num.isNaN() == expected, nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
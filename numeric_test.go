@@ -0,0 +1,299 @@
+package conditions
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// cents is a fixed-point Decimal with two decimal places.
+type cents int64
+
+func (c cents) Rat() *big.Rat {
+	return big.NewRat(int64(c), 100)
+}
+
+func TestExactNumbers(t *testing.T) {
+	cond := NewConditions()
+	lenient := NewConditions(WithCoercion(CoercionLenient))
+	tenth, fifth := 0.1, 0.2
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name      string
+		cond      *Conditions
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $gt with int64 above 2^53",
+			cond:      cond,
+			condition: map[string]any{"{{id}}": map[string]any{"$gt": int64(1<<53 + 1)}},
+			instance:  map[string]any{"id": int64(1<<53 + 2)},
+			want:      true,
+		},
+		{
+			name:      "Test $gt with uint64 and int64",
+			cond:      cond,
+			condition: map[string]any{"{{id}}": map[string]any{"$gt": int64(-1)}},
+			instance:  map[string]any{"id": uint64(math.MaxUint64)},
+			want:      true,
+		},
+		{
+			name:      "Test $lt with int64 and float64 near 2^63",
+			cond:      cond,
+			condition: map[string]any{"{{id}}": map[string]any{"$lt": float64(1 << 63)}},
+			instance:  map[string]any{"id": int64(math.MaxInt64)},
+			want:      true,
+		},
+		{
+			name:      "Test $gte with big.Int",
+			cond:      cond,
+			condition: map[string]any{"{{balance}}": map[string]any{"$gte": huge}},
+			instance:  map[string]any{"balance": new(big.Int).Add(huge, big.NewInt(1))},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with big.Rat and json.Number",
+			cond:      cond,
+			condition: map[string]any{"{{price}}": map[string]any{"$eq": json.Number("0.30")}},
+			instance:  map[string]any{"price": new(big.Rat).Add(big.NewRat(1, 10), big.NewRat(2, 10))},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with float sum and json.Number",
+			cond:      cond,
+			condition: map[string]any{"{{price}}": map[string]any{"$eq": json.Number("0.3")}},
+			instance:  map[string]any{"price": tenth + fifth},
+			want:      false,
+		},
+		{
+			name:      "Test $eq with float and json.Number",
+			cond:      cond,
+			condition: map[string]any{"{{price}}": map[string]any{"$eq": json.Number("0.1")}},
+			instance:  map[string]any{"price": 0.1},
+			want:      true,
+		},
+		{
+			name:      "Test $between with big.Float",
+			cond:      cond,
+			condition: map[string]any{"{{ratio}}": map[string]any{"$between": []any{big.NewFloat(0.5), 1}}},
+			instance:  map[string]any{"ratio": big.NewFloat(0.75)},
+			want:      true,
+		},
+		{
+			name:      "Test $lt with Decimal",
+			cond:      cond,
+			condition: map[string]any{"{{total}}": map[string]any{"$lt": cents(1000)}},
+			instance:  map[string]any{"total": json.Number("9.99")},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with Decimal and int",
+			cond:      cond,
+			condition: map[string]any{"{{total}}": map[string]any{"$eq": 10}},
+			instance:  map[string]any{"total": cents(1000)},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with decimal string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{total}}": map[string]any{"$eq": cents(30)}},
+			instance:  map[string]any{"total": "0.30"},
+			want:      true,
+		},
+		{
+			name:      "Test $gt with two decimal strings and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{price}}": map[string]any{"$gt": "10.25"}},
+			instance:  map[string]any{"price": "9.50"},
+			want:      false,
+		},
+		{
+			name:      "Test $eq with two decimal strings and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{price}}": map[string]any{"$eq": "9.5"}},
+			instance:  map[string]any{"price": "9.50"},
+			want:      true,
+		},
+		{
+			name:      "Test $between with decimal strings and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{price}}": map[string]any{"$between": []any{"9.5", "10.25"}}},
+			instance:  map[string]any{"price": "10.0"},
+			want:      true,
+		},
+		{
+			name:      "Test $gt with two decimal strings without coercion compares text",
+			cond:      cond,
+			condition: map[string]any{"{{price}}": map[string]any{"$gt": "10.25"}},
+			instance:  map[string]any{"price": "9.50"},
+			want:      true,
+		},
+		{
+			name:      "Test $gt with large decimal string and lenient coercion",
+			cond:      lenient,
+			condition: map[string]any{"{{id}}": map[string]any{"$gt": uint64(9007199254740992)}},
+			instance:  map[string]any{"id": "9007199254740993"},
+			want:      true,
+		},
+		{
+			name:      "Test $eq with int and float without coercion",
+			cond:      cond,
+			condition: map[string]any{"{{count}}": map[string]any{"$eq": 3.0}},
+			instance:  map[string]any{"count": 3},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericOperators(t *testing.T) {
+	cond := NewConditions()
+	nan, inf := math.NaN(), math.Inf(1)
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $mod operator true",
+			condition: map[string]any{"{{qty}}": map[string]any{"$mod": []int{4, 1}}},
+			instance:  map[string]any{"qty": 9},
+			want:      true,
+		},
+		{
+			name:      "Test $mod operator with negative fact",
+			condition: map[string]any{"{{qty}}": map[string]any{"$mod": []int{4, -1}}},
+			instance:  map[string]any{"qty": -9},
+			want:      true,
+		},
+		{
+			name:      "Test $mod operator with uint64 above 2^53",
+			condition: map[string]any{"{{id}}": map[string]any{"$mod": []any{10, 7}}},
+			instance:  map[string]any{"id": uint64(18446744073709551607)},
+			want:      true,
+		},
+		{
+			name:      "Test $mod operator with decimal",
+			condition: map[string]any{"{{price}}": map[string]any{"$mod": []any{json.Number("0.05"), 0}}},
+			instance:  map[string]any{"price": json.Number("19.95")},
+			want:      true,
+		},
+		{
+			name:      "Test $mod operator with zero divisor",
+			condition: map[string]any{"{{qty}}": map[string]any{"$mod": []int{0, 0}}},
+			instance:  map[string]any{"qty": 9},
+			want:      false,
+		},
+		{
+			name:      "Test $approx operator with default tolerance",
+			condition: map[string]any{"{{sum}}": map[string]any{"$approx": 0.3}},
+			instance:  map[string]any{"sum": 0.30000000000000004},
+			want:      true,
+		},
+		{
+			name:      "Test $approx operator with absolute tolerance",
+			condition: map[string]any{"{{temp}}": map[string]any{"$approx": map[string]any{"value": 21.5, "abs": 0.1}}},
+			instance:  map[string]any{"temp": 21.58},
+			want:      true,
+		},
+		{
+			name:      "Test $approx operator outside relative tolerance",
+			condition: map[string]any{"{{temp}}": map[string]any{"$approx": map[string]any{"value": 100, "rel": 0.01}}},
+			instance:  map[string]any{"temp": 98.5},
+			want:      false,
+		},
+		{
+			name:      "Test $approx operator with NaN",
+			condition: map[string]any{"{{temp}}": map[string]any{"$approx": map[string]any{"value": 0, "abs": 1}}},
+			instance:  map[string]any{"temp": nan},
+			want:      false,
+		},
+		{
+			name:      "Test $finite operator true",
+			condition: map[string]any{"{{temp}}": map[string]any{"$finite": true}},
+			instance:  map[string]any{"temp": 21.5},
+			want:      true,
+		},
+		{
+			name:      "Test $finite operator with infinity",
+			condition: map[string]any{"{{temp}}": map[string]any{"$finite": false}},
+			instance:  map[string]any{"temp": inf},
+			want:      true,
+		},
+		{
+			name:      "Test $finite operator with string",
+			condition: map[string]any{"{{temp}}": map[string]any{"$finite": false}},
+			instance:  map[string]any{"temp": "hot"},
+			want:      false,
+		},
+		{
+			name:      "Test $nan operator true",
+			condition: map[string]any{"{{temp}}": map[string]any{"$nan": true}},
+			instance:  map[string]any{"temp": nan},
+			want:      true,
+		},
+		{
+			name:      "Test $lt operator with NaN",
+			condition: map[string]any{"{{temp}}": map[string]any{"$lt": 100}},
+			instance:  map[string]any{"temp": nan},
+			want:      false,
+		},
+		{
+			name:      "Test $gte operator with NaN",
+			condition: map[string]any{"{{temp}}": map[string]any{"$gte": 100}},
+			instance:  map[string]any{"temp": nan},
+			want:      false,
+		},
+		{
+			name:      "Test $between operator with NaN bound",
+			condition: map[string]any{"{{temp}}": map[string]any{"$between": []float64{nan, 100}}},
+			instance:  map[string]any{"temp": 50.0},
+			want:      false,
+		},
+		{
+			name:      "Test $eq operator with NaN",
+			condition: map[string]any{"{{temp}}": map[string]any{"$eq": nan}},
+			instance:  map[string]any{"temp": nan},
+			want:      false,
+		},
+		{
+			name:      "Test $ne operator with NaN",
+			condition: map[string]any{"{{temp}}": map[string]any{"$ne": nan}},
+			instance:  map[string]any{"temp": nan},
+			want:      true,
+		},
+		{
+			name:      "Test $gt operator with infinity and big.Int",
+			condition: map[string]any{"{{limit}}": map[string]any{"$gt": new(big.Int).Lsh(big.NewInt(1), 2000)}},
+			instance:  map[string]any{"limit": inf},
+			want:      true,
+		},
+		{
+			name:      "Test $lt operator with negative infinity",
+			condition: map[string]any{"{{limit}}": map[string]any{"$lt": -1e308}},
+			instance:  map[string]any{"limit": math.Inf(-1)},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ const (
 	BLANK     SimpleOperatorsEnum = "$blank"     // Represents the blank operator
 	TRULY     SimpleOperatorsEnum = "$truly"     // Represents the truly operator
 	FALSY     SimpleOperatorsEnum = "$falsy"     // Represents the falsy operator
+	ZERO      SimpleOperatorsEnum = "$zero"      // Represents the zero value operator
 )
 
 type CommonOperatorsEnum string
@@ -35,6 +36,98 @@ const (
 	SOME    CommonOperatorsEnum = "$some"    // Represents the some operator
 	EVERY   CommonOperatorsEnum = "$every"   // Represents the every operator
 	NOONE   CommonOperatorsEnum = "$noone"   // Represents the no one operator
+
+	CONTAINS  CommonOperatorsEnum = "$contains"  // Represents the substring operator
+	IEQ       CommonOperatorsEnum = "$ieq"       // Represents the case-insensitive equal operator
+	ISW       CommonOperatorsEnum = "$isw"       // Represents the case-insensitive starts with operator
+	IEW       CommonOperatorsEnum = "$iew"       // Represents the case-insensitive ends with operator
+	ICONTAINS CommonOperatorsEnum = "$icontains" // Represents the case-insensitive substring operator
+	LIKE      CommonOperatorsEnum = "$like"      // Represents the SQL LIKE pattern operator
+	ILIKE     CommonOperatorsEnum = "$ilike"     // Represents the case-insensitive SQL LIKE pattern operator
+	GLOB      CommonOperatorsEnum = "$glob"      // Represents the shell glob pattern operator
+	LEN       CommonOperatorsEnum = "$len"       // Represents the string length operator
+
+	WITHINLAST CommonOperatorsEnum = "$withinLast" // Represents the within last time span operator
+	OLDERTHAN  CommonOperatorsEnum = "$olderThan"  // Represents the older than time span operator
+	SAMEDAY    CommonOperatorsEnum = "$sameDay"    // Represents the same calendar day operator
+	WEEKDAY    CommonOperatorsEnum = "$weekday"    // Represents the day of week operator
+	AGE        CommonOperatorsEnum = "$age"        // Represents the full years since date operator
+
+	TIMEWINDOW    CommonOperatorsEnum = "$timeWindow"    // Represents the time of day window operator
+	CRON          CommonOperatorsEnum = "$cron"          // Represents the cron expression operator
+	BUSINESSHOURS CommonOperatorsEnum = "$businessHours" // Represents the business hours operator
+
+	QTYEQ      CommonOperatorsEnum = "$qtyEq"      // Represents the quantity equal operator
+	QTYLT      CommonOperatorsEnum = "$qtyLt"      // Represents the quantity less than operator
+	QTYGT      CommonOperatorsEnum = "$qtyGt"      // Represents the quantity greater than operator
+	QTYLTE     CommonOperatorsEnum = "$qtyLte"     // Represents the quantity less than or equal to operator
+	QTYGTE     CommonOperatorsEnum = "$qtyGte"     // Represents the quantity greater than or equal to operator
+	QTYBETWEEN CommonOperatorsEnum = "$qtyBetween" // Represents the quantity between operator
+
+	SEMVERGT        CommonOperatorsEnum = "$semverGt"        // Represents the semantic version greater than operator
+	SEMVERLT        CommonOperatorsEnum = "$semverLt"        // Represents the semantic version less than operator
+	SEMVERBETWEEN   CommonOperatorsEnum = "$semverBetween"   // Represents the semantic version between operator
+	SEMVERSATISFIES CommonOperatorsEnum = "$semverSatisfies" // Represents the semantic version range operator
+
+	CIDR      CommonOperatorsEnum = "$cidr"      // Represents the IP address in CIDR prefixes operator
+	IPV4      CommonOperatorsEnum = "$ipv4"      // Represents the IPv4 address operator
+	IPV6      CommonOperatorsEnum = "$ipv6"      // Represents the IPv6 address operator
+	IPPRIVATE CommonOperatorsEnum = "$ipPrivate" // Represents the private IP address operator
+	IPRANGE   CommonOperatorsEnum = "$ipRange"   // Represents the IP address range operator
+
+	GEOWITHIN CommonOperatorsEnum = "$geoWithin" // Represents the point in polygon operator
+	GEONEAR   CommonOperatorsEnum = "$geoNear"   // Represents the point within distance operator
+	GEOBOX    CommonOperatorsEnum = "$geoBox"    // Represents the point in bounding box operator
+
+	FORMAT CommonOperatorsEnum = "$format" // Represents the named format validation operator
+	SCHEMA CommonOperatorsEnum = "$schema" // Represents the JSON Schema validation operator
+
+	TYPE      CommonOperatorsEnum = "$type"      // Represents the type check operator
+	ISSTRING  CommonOperatorsEnum = "$isString"  // Represents the string type check operator
+	ISNUMBER  CommonOperatorsEnum = "$isNumber"  // Represents the number type check operator
+	ISINTEGER CommonOperatorsEnum = "$isInteger" // Represents the integer type check operator
+	ISBOOL    CommonOperatorsEnum = "$isBool"    // Represents the bool type check operator
+	ISARRAY   CommonOperatorsEnum = "$isArray"   // Represents the array type check operator
+	ISOBJECT  CommonOperatorsEnum = "$isObject"  // Represents the object type check operator
+	ISNULL    CommonOperatorsEnum = "$isNull"    // Represents the null type check operator
+	ISTIME    CommonOperatorsEnum = "$isTime"    // Represents the time type check operator
+
+	BITSALLSET   CommonOperatorsEnum = "$bitsAllSet"   // Represents the all bits set operator
+	BITSANYSET   CommonOperatorsEnum = "$bitsAnySet"   // Represents the any bit set operator
+	BITSALLCLEAR CommonOperatorsEnum = "$bitsAllClear" // Represents the all bits clear operator
+	BITSANYCLEAR CommonOperatorsEnum = "$bitsAnyClear" // Represents the any bit clear operator
+
+	MOD    CommonOperatorsEnum = "$mod"    // Represents the modulo operator
+	APPROX CommonOperatorsEnum = "$approx" // Represents the approximately equal operator
+	FINITE CommonOperatorsEnum = "$finite" // Represents the finite number operator
+	NAN    CommonOperatorsEnum = "$nan"    // Represents the not a number operator
+
+	ELEMMATCH CommonOperatorsEnum = "$elemMatch" // Represents the element match operator
+
+	ALL        CommonOperatorsEnum = "$all"        // Represents the contains all values operator
+	SUPERSET   CommonOperatorsEnum = "$superset"   // Represents the superset operator
+	SUBSET     CommonOperatorsEnum = "$subset"     // Represents the subset operator
+	DISJOINT   CommonOperatorsEnum = "$disjoint"   // Represents the no common values operator
+	INTERSECTS CommonOperatorsEnum = "$intersects" // Represents the common values operator
+	SETEQUALS  CommonOperatorsEnum = "$setEquals"  // Represents the same set of values operator
+
+	SIZE  CommonOperatorsEnum = "$size"  // Represents the collection size operator
+	SUM   CommonOperatorsEnum = "$sum"   // Represents the sum aggregate operator
+	AVG   CommonOperatorsEnum = "$avg"   // Represents the average aggregate operator
+	MIN   CommonOperatorsEnum = "$min"   // Represents the minimum aggregate operator
+	MAX   CommonOperatorsEnum = "$max"   // Represents the maximum aggregate operator
+	COUNT CommonOperatorsEnum = "$count" // Represents the count aggregate operator
+
+	HASKEY     CommonOperatorsEnum = "$hasKey"     // Represents the has map key operator
+	HASALLKEYS CommonOperatorsEnum = "$hasAllKeys" // Represents the has all map keys operator
+	HASANYKEY  CommonOperatorsEnum = "$hasAnyKey"  // Represents the has any map key operator
+	HASVALUE   CommonOperatorsEnum = "$hasValue"   // Represents the has map value operator
+	ANYVALUE   CommonOperatorsEnum = "$anyValue"   // Represents the any map value operator
+	ALLVALUES  CommonOperatorsEnum = "$allValues"  // Represents the all map values operator
+
+	UNIQUE        CommonOperatorsEnum = "$unique"        // Represents the unique elements operator
+	SORTED        CommonOperatorsEnum = "$sorted"        // Represents the sorted elements operator
+	DISTINCTCOUNT CommonOperatorsEnum = "$distinctCount" // Represents the distinct element count operator
 )
 
 type LogicOperatorsEnum string
@@ -55,6 +148,7 @@ var stringToSimpleOperator = map[string]SimpleOperatorsEnum{
 	"$blank":     BLANK,
 	"$truly":     TRULY,
 	"$falsy":     FALSY,
+	"$zero":      ZERO,
 }
 
 var stringToCommonOperator = map[string]CommonOperatorsEnum{
@@ -77,6 +171,98 @@ var stringToCommonOperator = map[string]CommonOperatorsEnum{
 	"$some":    SOME,
 	"$every":   EVERY,
 	"$noone":   NOONE,
+
+	"$contains":  CONTAINS,
+	"$ieq":       IEQ,
+	"$isw":       ISW,
+	"$iew":       IEW,
+	"$icontains": ICONTAINS,
+	"$like":      LIKE,
+	"$ilike":     ILIKE,
+	"$glob":      GLOB,
+	"$len":       LEN,
+
+	"$withinLast": WITHINLAST,
+	"$olderThan":  OLDERTHAN,
+	"$sameDay":    SAMEDAY,
+	"$weekday":    WEEKDAY,
+	"$age":        AGE,
+
+	"$timeWindow":    TIMEWINDOW,
+	"$cron":          CRON,
+	"$businessHours": BUSINESSHOURS,
+
+	"$qtyEq":      QTYEQ,
+	"$qtyLt":      QTYLT,
+	"$qtyGt":      QTYGT,
+	"$qtyLte":     QTYLTE,
+	"$qtyGte":     QTYGTE,
+	"$qtyBetween": QTYBETWEEN,
+
+	"$semverGt":        SEMVERGT,
+	"$semverLt":        SEMVERLT,
+	"$semverBetween":   SEMVERBETWEEN,
+	"$semverSatisfies": SEMVERSATISFIES,
+
+	"$cidr":      CIDR,
+	"$ipv4":      IPV4,
+	"$ipv6":      IPV6,
+	"$ipPrivate": IPPRIVATE,
+	"$ipRange":   IPRANGE,
+
+	"$geoWithin": GEOWITHIN,
+	"$geoNear":   GEONEAR,
+	"$geoBox":    GEOBOX,
+
+	"$format": FORMAT,
+	"$schema": SCHEMA,
+
+	"$type":      TYPE,
+	"$isString":  ISSTRING,
+	"$isNumber":  ISNUMBER,
+	"$isInteger": ISINTEGER,
+	"$isBool":    ISBOOL,
+	"$isArray":   ISARRAY,
+	"$isObject":  ISOBJECT,
+	"$isNull":    ISNULL,
+	"$isTime":    ISTIME,
+
+	"$bitsAllSet":   BITSALLSET,
+	"$bitsAnySet":   BITSANYSET,
+	"$bitsAllClear": BITSALLCLEAR,
+	"$bitsAnyClear": BITSANYCLEAR,
+
+	"$mod":    MOD,
+	"$approx": APPROX,
+	"$finite": FINITE,
+	"$nan":    NAN,
+
+	"$elemMatch": ELEMMATCH,
+
+	"$all":        ALL,
+	"$superset":   SUPERSET,
+	"$subset":     SUBSET,
+	"$disjoint":   DISJOINT,
+	"$intersects": INTERSECTS,
+	"$setEquals":  SETEQUALS,
+
+	"$size":  SIZE,
+	"$sum":   SUM,
+	"$avg":   AVG,
+	"$min":   MIN,
+	"$max":   MAX,
+	"$count": COUNT,
+
+	"$hasKey":     HASKEY,
+	"$hasAllKeys": HASALLKEYS,
+	"$hasAnyKey":  HASANYKEY,
+	"$hasValue":   HASVALUE,
+	"$anyValue":   ANYVALUE,
+	"$allValues":  ALLVALUES,
+
+	"$unique":        UNIQUE,
+	"$sorted":        SORTED,
+	"$distinctCount": DISTINCTCOUNT,
 }
 
 var stringToLogicOperator = map[string]LogicOperatorsEnum{
@@ -86,6 +272,24 @@ var stringToLogicOperator = map[string]LogicOperatorsEnum{
 	"$not": NOT,
 }
 
-var SimpleOperators = []SimpleOperatorsEnum{NULL, DEFINED, UNDEFINED, EXIST, EMPTY, BLANK, TRULY, FALSY}
-var CommonOperators = []CommonOperatorsEnum{EQ, NE, LT, GT, LTE, GTE, RE, IN, NI, SW, EW, INCL, EXCL, HAS, POWER, BETWEEN, SOME, EVERY, NOONE}
+var SimpleOperators = []SimpleOperatorsEnum{NULL, DEFINED, UNDEFINED, EXIST, EMPTY, BLANK, TRULY, FALSY, ZERO}
+var CommonOperators = []CommonOperatorsEnum{
+	EQ, NE, LT, GT, LTE, GTE, RE, IN, NI, SW, EW, INCL, EXCL, HAS, POWER, BETWEEN, SOME, EVERY, NOONE,
+	CONTAINS, IEQ, ISW, IEW, ICONTAINS, LIKE, ILIKE, GLOB, LEN,
+	WITHINLAST, OLDERTHAN, SAMEDAY, WEEKDAY, AGE,
+	TIMEWINDOW, CRON, BUSINESSHOURS,
+	QTYEQ, QTYLT, QTYGT, QTYLTE, QTYGTE, QTYBETWEEN,
+	SEMVERGT, SEMVERLT, SEMVERBETWEEN, SEMVERSATISFIES,
+	CIDR, IPV4, IPV6, IPPRIVATE, IPRANGE,
+	GEOWITHIN, GEONEAR, GEOBOX,
+	FORMAT, SCHEMA,
+	TYPE, ISSTRING, ISNUMBER, ISINTEGER, ISBOOL, ISARRAY, ISOBJECT, ISNULL, ISTIME,
+	BITSALLSET, BITSANYSET, BITSALLCLEAR, BITSANYCLEAR,
+	MOD, APPROX, FINITE, NAN,
+	ELEMMATCH,
+	ALL, SUPERSET, SUBSET, DISJOINT, INTERSECTS, SETEQUALS,
+	SIZE, SUM, AVG, MIN, MAX, COUNT,
+	HASKEY, HASALLKEYS, HASANYKEY, HASVALUE, ANYVALUE, ALLVALUES,
+	UNIQUE, SORTED, DISTINCTCOUNT,
+}
 var LogicOperators = []LogicOperatorsEnum{OR, XOR, AND, NOT}
@@ -0,0 +1,218 @@
+package conditions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Dimension is the physical dimension of a unit, like mass or length. Only quantities of the same dimension are comparable.
+type Dimension string
+
+const (
+	Mass   Dimension = "mass"   // Base unit is the kilogram
+	Length Dimension = "length" // Base unit is the meter
+	Volume Dimension = "volume" // Base unit is the liter
+)
+
+// Unit is a unit of measurement with its conversion factor to the base unit of its dimension.
+type Unit struct {
+	Symbol    string
+	Dimension Dimension
+	Factor    float64
+}
+
+// Quantity is a value with a unit, like 5 kg or 12 in.
+type Quantity struct {
+	Value float64
+	Unit  Unit
+}
+
+// Base returns the value converted to the base unit of the quantity's dimension.
+func (q Quantity) Base() float64 {
+	return q.Value * q.Unit.Factor
+}
+
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Value, 'f', -1, 64) + q.Unit.Symbol
+}
+
+// UnitRegistry maps unit symbols to units, safe for concurrent use.
+type UnitRegistry struct {
+	mu    sync.RWMutex
+	units map[string]Unit
+}
+
+// NewUnitRegistry returns a registry with common metric and imperial units of mass, length and volume.
+func NewUnitRegistry() *UnitRegistry {
+	r := &UnitRegistry{units: make(map[string]Unit)}
+	for _, unit := range []Unit{
+		{"mg", Mass, 1e-6},
+		{"g", Mass, 1e-3},
+		{"kg", Mass, 1},
+		{"t", Mass, 1e3},
+		{"oz", Mass, 0.028349523125},
+		{"lb", Mass, 0.45359237},
+		{"mm", Length, 1e-3},
+		{"cm", Length, 1e-2},
+		{"m", Length, 1},
+		{"km", Length, 1e3},
+		{"in", Length, 0.0254},
+		{"ft", Length, 0.3048},
+		{"yd", Length, 0.9144},
+		{"mi", Length, 1609.344},
+		{"ml", Volume, 1e-3},
+		{"cl", Volume, 1e-2},
+		{"l", Volume, 1},
+		{"gal", Volume, 3.785411784},
+	} {
+		r.units[unit.Symbol] = unit
+	}
+	return r
+}
+
+// Register adds or replaces a unit.
+func (r *UnitRegistry) Register(symbol string, dimension Dimension, factor float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.units[symbol] = Unit{Symbol: symbol, Dimension: dimension, Factor: factor}
+}
+
+// Lookup returns the unit for a symbol, falling back to a case-insensitive match.
+func (r *UnitRegistry) Lookup(symbol string) (Unit, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if unit, ok := r.units[symbol]; ok {
+		return unit, true
+	}
+	unit, ok := r.units[strings.ToLower(symbol)]
+	return unit, ok
+}
+
+// Parse parses quantities like "5kg", "12 in" or "-0.5 l".
+func (r *UnitRegistry) Parse(value string) (Quantity, error) {
+	str := strings.TrimSpace(value)
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != '-' && r != '+' && r != 'e' && r != 'E'
+	})
+	// An exponent marker directly followed by a letter is part of the unit, not the number.
+	for i > 0 && (str[i-1] == 'e' || str[i-1] == 'E') {
+		i--
+	}
+	if i <= 0 {
+		return Quantity{}, fmt.Errorf("invalid quantity %q", value)
+	}
+
+	number, err := strconv.ParseFloat(str[:i], 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("invalid quantity %q: %w", value, err)
+	}
+	symbol := strings.TrimSpace(str[i:])
+	unit, ok := r.Lookup(symbol)
+	if !ok {
+		return Quantity{}, fmt.Errorf("unknown unit %q in quantity %q", symbol, value)
+	}
+	return Quantity{Value: number, Unit: unit}, nil
+}
+
+// WithUnitRegistry sets the units used by the quantity operators.
+func WithUnitRegistry(registry *UnitRegistry) Option {
+	return func(c *Conditions) {
+		c.units = registry
+	}
+}
+
+// toQuantity converts a Quantity, a string like "5kg" or a map like {"value": 5, "unit": "kg"} to a Quantity.
+func (c *Conditions) toQuantity(value any) (Quantity, error) {
+	switch v := value.(type) {
+	case Quantity:
+		return v, nil
+	case string:
+		return c.units.Parse(v)
+	case map[string]any:
+		number, ok := toFloat64(v["value"])
+		if !ok {
+			return Quantity{}, fmt.Errorf("expected numeric value in quantity, got %T", v["value"])
+		}
+		symbol, ok := v["unit"].(string)
+		if !ok {
+			return Quantity{}, fmt.Errorf("expected string unit in quantity, got %T", v["unit"])
+		}
+		unit, ok := c.units.Lookup(symbol)
+		if !ok {
+			return Quantity{}, fmt.Errorf("unknown unit %q", symbol)
+		}
+		return Quantity{Value: number, Unit: unit}, nil
+	default:
+		return Quantity{}, fmt.Errorf("expected quantity, got %T", value)
+	}
+}
+
+// compareQuantities converts both values to the base unit of their dimension and compares them.
+// Values within a relative tolerance of 1e-9 are equal, so that 12in equals 30.48cm.
+func (c *Conditions) compareQuantities(v1, v2 any) (int, error) {
+	q1, err := c.toQuantity(v1)
+	if err != nil {
+		return 0, err
+	}
+	q2, err := c.toQuantity(v2)
+	if err != nil {
+		return 0, err
+	}
+	if q1.Unit.Dimension != q2.Unit.Dimension {
+		return 0, fmt.Errorf("cannot compare %s of dimension %s with %s of dimension %s", q1, q1.Unit.Dimension, q2, q2.Unit.Dimension)
+	}
+
+	b1, b2 := q1.Base(), q2.Base()
+	if math.Abs(b1-b2) <= 1e-9*math.Max(math.Abs(b1), math.Abs(b2)) {
+		return 0, nil
+	}
+	if b1 < b2 {
+		return -1, nil
+	}
+	return 1, nil
+}
+
+// checkQuantityOperator evaluates the quantity comparison operators.
+func (c *Conditions) checkQuantityOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	if operator == QTYBETWEEN {
+		val := reflect.ValueOf(conditionValue)
+		if val.Kind() != reflect.Slice || val.Len() != 2 {
+			return false, fmt.Errorf("expected condition to be a slice with exactly two elements")
+		}
+		lower, err := c.compareQuantities(fact, val.Index(0).Interface())
+		if err != nil || lower < 0 {
+			return false, err
+		}
+		upper, err := c.compareQuantities(fact, val.Index(1).Interface())
+		if err != nil {
+			return false, err
+		}
+		return upper <= 0, nil
+	}
+
+	result, err := c.compareQuantities(fact, conditionValue)
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case QTYEQ:
+		return result == 0, nil
+	case QTYLT:
+		return result < 0, nil
+	case QTYGT:
+		return result > 0, nil
+	case QTYLTE:
+		return result <= 0, nil
+	case QTYGTE:
+		return result >= 0, nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
@@ -0,0 +1,82 @@
+package conditions
+
+import (
+	"testing"
+)
+
+func TestQuantityOperators(t *testing.T) {
+	units := NewUnitRegistry()
+	units.Register("st", Mass, 6.35029318)
+	cond := NewConditions(WithUnitRegistry(units))
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $qtyGt operator with different mass units",
+			condition: map[string]any{"{{weight}}": map[string]any{"$qtyGt": "3000g"}},
+			instance:  map[string]any{"weight": "5kg"},
+			want:      true,
+		},
+		{
+			name:      "Test $qtyLt operator with imperial and metric lengths",
+			condition: map[string]any{"{{length}}": map[string]any{"$qtyLt": "30cm"}},
+			instance:  map[string]any{"length": "12 in"},
+			want:      false,
+		},
+		{
+			name:      "Test $qtyEq operator after conversion",
+			condition: map[string]any{"{{length}}": map[string]any{"$qtyEq": "30.48cm"}},
+			instance:  map[string]any{"length": "12in"},
+			want:      true,
+		},
+		{
+			name:      "Test $qtyLte operator with map fact",
+			condition: map[string]any{"{{volume}}": map[string]any{"$qtyLte": "1 gal"}},
+			instance:  map[string]any{"volume": map[string]any{"value": 3.5, "unit": "l"}},
+			want:      true,
+		},
+		{
+			name:      "Test $qtyGte operator with Quantity fact and custom unit",
+			condition: map[string]any{"{{weight}}": map[string]any{"$qtyGte": "10st"}},
+			instance:  map[string]any{"weight": Quantity{Value: 70, Unit: Unit{Symbol: "kg", Dimension: Mass, Factor: 1}}},
+			want:      true,
+		},
+		{
+			name:      "Test $qtyBetween operator true",
+			condition: map[string]any{"{{parcel.weight}}": map[string]any{"$qtyBetween": []string{"500g", "2lb"}}},
+			instance:  map[string]any{"parcel": map[string]any{"weight": "0.75kg"}},
+			want:      true,
+		},
+		{
+			name:      "Test $qtyGt operator with dimension mismatch",
+			condition: map[string]any{"{{weight}}": map[string]any{"$qtyGt": "1m"}},
+			instance:  map[string]any{"weight": "5kg"},
+			want:      false,
+		},
+		{
+			name:      "Test $qtyGt operator with unknown unit",
+			condition: map[string]any{"{{weight}}": map[string]any{"$qtyGt": "1 parsec"}},
+			instance:  map[string]any{"weight": "5kg"},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareQuantitiesDimensionMismatch(t *testing.T) {
+	cond := NewConditions()
+	if _, err := cond.compareQuantities("5kg", "3m"); err == nil {
+		t.Errorf("expected error when comparing mass with length")
+	}
+}
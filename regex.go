@@ -0,0 +1,172 @@
+package conditions
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const defaultRegexCacheSize = 256
+
+// regexCache is a bounded LRU cache of compiled regular expressions, safe for concurrent use.
+type regexCache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[string]*list.Element
+}
+
+type regexCacheEntry struct {
+	key string
+	re  *regexp.Regexp
+}
+
+func newRegexCache(size int) *regexCache {
+	return &regexCache{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (rc *regexCache) get(key string) (*regexp.Regexp, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if elem, ok := rc.items[key]; ok {
+		rc.order.MoveToFront(elem)
+		return elem.Value.(*regexCacheEntry).re, true
+	}
+	return nil, false
+}
+
+func (rc *regexCache) put(key string, re *regexp.Regexp) {
+	if rc.size <= 0 {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if elem, ok := rc.items[key]; ok {
+		rc.order.MoveToFront(elem)
+		elem.Value.(*regexCacheEntry).re = re
+		return
+	}
+
+	rc.items[key] = rc.order.PushFront(&regexCacheEntry{key: key, re: re})
+	for rc.order.Len() > rc.size {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.items, oldest.Value.(*regexCacheEntry).key)
+	}
+}
+
+func (rc *regexCache) len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.order.Len()
+}
+
+// regexOperand is the parsed form of a $re operand, either a plain pattern string
+// or an object like {"pattern": "...", "flags": "i", "stringify": true}.
+type regexOperand struct {
+	pattern   string
+	flags     string
+	stringify bool
+}
+
+func parseRegexOperand(value any) (regexOperand, error) {
+	switch v := value.(type) {
+	case string:
+		return regexOperand{pattern: v}, nil
+	case map[string]any:
+		var operand regexOperand
+		pattern, ok := v["pattern"].(string)
+		if !ok {
+			return operand, fmt.Errorf("expected string pattern for $re operator, got %T", v["pattern"])
+		}
+		operand.pattern = pattern
+		if flags, exists := v["flags"]; exists {
+			if operand.flags, ok = flags.(string); !ok {
+				return operand, fmt.Errorf("expected string flags for $re operator, got %T", flags)
+			}
+		}
+		if stringify, exists := v["stringify"]; exists {
+			if operand.stringify, ok = stringify.(bool); !ok {
+				return operand, fmt.Errorf("expected bool stringify for $re operator, got %T", stringify)
+			}
+		}
+		return operand, nil
+	default:
+		return regexOperand{}, fmt.Errorf("expected string or object for $re operator, got %T", value)
+	}
+}
+
+// compileRegex returns the compiled pattern with the given flags, using the shared cache.
+// Supported flags are i (case-insensitive), m (multiline), s (dot matches newline) and U (ungreedy).
+func (c *Conditions) compileRegex(pattern string, flags string) (*regexp.Regexp, error) {
+	if err := c.checkPatternLength(RE, pattern); err != nil {
+		return nil, err
+	}
+
+	for _, flag := range flags {
+		if !strings.ContainsRune("imsU", flag) {
+			return nil, fmt.Errorf("unsupported $re flag %q", flag)
+		}
+	}
+
+	expr := pattern
+	if flags != "" {
+		expr = "(?" + flags + ")" + pattern
+	}
+
+	return c.compileCachedRegex(expr)
+}
+
+// checkPatternLength rejects a pattern longer than the limit set with WithMaxPatternLength.
+func (c *Conditions) checkPatternLength(operator CommonOperatorsEnum, pattern string) error {
+	if c.maxPatternLength > 0 && len(pattern) > c.maxPatternLength {
+		return fmt.Errorf("%s pattern length %d exceeds maximum of %d", operator, len(pattern), c.maxPatternLength)
+	}
+	return nil
+}
+
+// compileCachedRegex compiles an already validated expression through the shared cache.
+func (c *Conditions) compileCachedRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := c.regexCache.get(expr); ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	c.regexCache.put(expr, re)
+	return re, nil
+}
+
+// checkRegex matches the fact against a $re operand.
+func (c *Conditions) checkRegex(fact any, conditionValue any) (bool, error) {
+	operand, err := parseRegexOperand(conditionValue)
+	if err != nil {
+		return false, err
+	}
+
+	re, err := c.compileRegex(operand.pattern, operand.flags)
+	if err != nil {
+		return false, err
+	}
+
+	str, ok := fact.(string)
+	if !ok {
+		if !operand.stringify || fact == nil {
+			return false, fmt.Errorf("expected string for regex match, got %T", fact)
+		}
+		str = fmt.Sprintf("%v", fact)
+	}
+
+	return re.MatchString(str), nil
+}
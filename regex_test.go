@@ -0,0 +1,134 @@
+package conditions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegexOperator(t *testing.T) {
+	cond := NewConditions(WithMaxPatternLength(32))
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name: "Test $re operator with plain pattern",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$re": "^J.*n$"},
+			},
+			instance: map[string]any{"name": "John"},
+			want:     true,
+		},
+		{
+			name: "Test $re operator case-sensitive by default",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$re": "^john$"},
+			},
+			instance: map[string]any{"name": "John"},
+			want:     false,
+		},
+		{
+			name: "Test $re operator with case-insensitive flag",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$re": map[string]any{"pattern": "^john$", "flags": "i"}},
+			},
+			instance: map[string]any{"name": "John"},
+			want:     true,
+		},
+		{
+			name: "Test $re operator with multiline flag",
+			condition: map[string]any{
+				"{{text}}": map[string]any{"$re": map[string]any{"pattern": "^second$", "flags": "m"}},
+			},
+			instance: map[string]any{"text": "first\nsecond\nthird"},
+			want:     true,
+		},
+		{
+			name: "Test $re operator with unsupported flag",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$re": map[string]any{"pattern": "^J", "flags": "x"}},
+			},
+			instance: map[string]any{"name": "John"},
+			want:     false,
+		},
+		{
+			name: "Test $re operator with non-string fact",
+			condition: map[string]any{
+				"{{code}}": map[string]any{"$re": "^4\\d\\d$"},
+			},
+			instance: map[string]any{"code": 404},
+			want:     false,
+		},
+		{
+			name: "Test $re operator with stringified fact",
+			condition: map[string]any{
+				"{{code}}": map[string]any{"$re": map[string]any{"pattern": "^4\\d\\d$", "stringify": true}},
+			},
+			instance: map[string]any{"code": 404},
+			want:     true,
+		},
+		{
+			name: "Test $re operator with too long pattern",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$re": "^" + strings.Repeat("a", 40)},
+			},
+			instance: map[string]any{"name": strings.Repeat("a", 40)},
+			want:     false,
+		},
+		{
+			name: "Test $like operator with too long pattern",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$like": strings.Repeat("a", 40) + "%"},
+			},
+			instance: map[string]any{"name": strings.Repeat("a", 40)},
+			want:     false,
+		},
+		{
+			name: "Test $glob operator with too long pattern",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$glob": strings.Repeat("a", 40) + "*"},
+			},
+			instance: map[string]any{"name": strings.Repeat("a", 40)},
+			want:     false,
+		},
+		{
+			name: "Test $re operator with invalid pattern",
+			condition: map[string]any{
+				"{{name}}": map[string]any{"$re": "(unclosed"},
+			},
+			instance: map[string]any{"name": "John"},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexCacheEviction(t *testing.T) {
+	cond := NewConditions(WithRegexCacheSize(2))
+
+	for _, pattern := range []string{"^a", "^b", "^a", "^c"} {
+		if _, err := cond.compileRegex(pattern, ""); err != nil {
+			t.Fatalf("compileRegex(%q) returned error: %v", pattern, err)
+		}
+	}
+
+	if got := cond.regexCache.len(); got != 2 {
+		t.Fatalf("cache size = %d, want 2", got)
+	}
+	if _, ok := cond.regexCache.get("^b"); ok {
+		t.Errorf("expected least recently used pattern ^b to be evicted")
+	}
+	if _, ok := cond.regexCache.get("^a"); !ok {
+		t.Errorf("expected recently used pattern ^a to be cached")
+	}
+}
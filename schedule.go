@@ -0,0 +1,470 @@
+package conditions
+
+import (
+	"bufio"
+	"container/list"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HolidayCalendar is a set of calendar dates excluded from business hours.
+type HolidayCalendar map[string]struct{}
+
+// NewHolidayCalendar builds a calendar from dates in the "2006-01-02" layout.
+func NewHolidayCalendar(dates ...string) (HolidayCalendar, error) {
+	calendar := make(HolidayCalendar, len(dates))
+	for _, date := range dates {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return nil, fmt.Errorf("invalid holiday date %q: %w", date, err)
+		}
+		calendar[date] = struct{}{}
+	}
+	return calendar, nil
+}
+
+// LoadHolidayCalendar reads a calendar from a local file with one date per line in the "2006-01-02"
+// layout, optionally followed by a description. Empty lines and lines starting with # are ignored.
+func LoadHolidayCalendar(path string) (HolidayCalendar, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var dates []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dates = append(dates, strings.Fields(line)[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewHolidayCalendar(dates...)
+}
+
+// Contains reports whether the calendar date of t is a holiday.
+func (hc HolidayCalendar) Contains(t time.Time) bool {
+	_, ok := hc[t.Format(time.DateOnly)]
+	return ok
+}
+
+// WithHolidayCalendar registers a holiday calendar under a name that $businessHours operands can refer to.
+func WithHolidayCalendar(name string, calendar HolidayCalendar) Option {
+	return func(c *Conditions) {
+		if c.holidays == nil {
+			c.holidays = make(map[string]HolidayCalendar)
+		}
+		c.holidays[name] = calendar
+	}
+}
+
+// timeWindow is a daily wall-clock window, e.g. 09:00-17:30 on weekdays in a given time zone.
+// A window whose end is before its start spans midnight and belongs to the day it starts on.
+type timeWindow struct {
+	start, end int                   // Seconds since midnight
+	days       map[time.Weekday]bool // nil means every day
+	location   *time.Location
+}
+
+func (w timeWindow) contains(t time.Time) bool {
+	local := t.In(w.location)
+	seconds := local.Hour()*3600 + local.Minute()*60 + local.Second()
+	day := local.Weekday()
+
+	if w.start <= w.end {
+		return seconds >= w.start && seconds < w.end && w.onDay(day)
+	}
+	if seconds >= w.start {
+		return w.onDay(day)
+	}
+	return seconds < w.end && w.onDay((day+6)%7)
+}
+
+func (w timeWindow) onDay(day time.Weekday) bool {
+	return w.days == nil || w.days[day]
+}
+
+// parseTimeWindow parses {"start": "09:00", "end": "17:30", "days": ["mon", "tue"], "tz": "Europe/Berlin"}
+// or a ["09:00", "17:30"] pair, starting from the given defaults.
+func (c *Conditions) parseTimeWindow(operator CommonOperatorsEnum, value any, window timeWindow) (timeWindow, error) {
+	if val := reflect.ValueOf(value); val.Kind() == reflect.Slice {
+		if val.Len() != 2 {
+			return window, fmt.Errorf("expected [start, end] pair for %s operator", operator)
+		}
+		value = map[string]any{"start": val.Index(0).Interface(), "end": val.Index(1).Interface()}
+	}
+
+	spec, ok := value.(map[string]any)
+	if !ok {
+		return window, fmt.Errorf("expected object or [start, end] pair for %s operator, got %T", operator, value)
+	}
+
+	var err error
+	if start, exists := spec["start"]; exists {
+		if window.start, err = parseClock(start); err != nil {
+			return window, err
+		}
+	}
+	if end, exists := spec["end"]; exists {
+		if window.end, err = parseClock(end); err != nil {
+			return window, err
+		}
+	}
+	if days, exists := spec["days"]; exists {
+		if window.days, err = parseWeekdays(days); err != nil {
+			return window, err
+		}
+	}
+	if tz, exists := spec["tz"]; exists {
+		if window.location, err = c.loadLocation(tz); err != nil {
+			return window, err
+		}
+	}
+	return window, nil
+}
+
+// parseClock parses a wall-clock time like "09:00" or "17:30:15" into seconds since midnight.
+// "24:00" is accepted as the end of the day.
+func parseClock(value any) (int, error) {
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("expected time of day string, got %T", value)
+	}
+
+	parts := strings.Split(str, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time of day %q", str)
+	}
+
+	seconds := 0
+	limits := []int{24, 59, 59}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || n > limits[i] {
+			return 0, fmt.Errorf("invalid time of day %q", str)
+		}
+		seconds = seconds*60 + n
+	}
+	if len(parts) == 2 {
+		seconds *= 60
+	}
+	if seconds > 24*3600 {
+		return 0, fmt.Errorf("invalid time of day %q", str)
+	}
+	return seconds, nil
+}
+
+func parseWeekdays(value any) (map[time.Weekday]bool, error) {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected list of weekdays, got %T", value)
+	}
+
+	days := make(map[time.Weekday]bool, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		item := val.Index(i).Interface()
+		name, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected weekday name, got %T", item)
+		}
+		day, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		days[day] = true
+	}
+	return days, nil
+}
+
+// locationCache is a bounded LRU cache of time zones loaded by name, safe for concurrent use.
+type locationCache struct {
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type locationCacheEntry struct {
+	name     string
+	location *time.Location
+}
+
+const maxCachedLocations = 64
+
+func newLocationCache() *locationCache {
+	return &locationCache{
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (lc *locationCache) get(name string) (*time.Location, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if elem, ok := lc.items[name]; ok {
+		lc.order.MoveToFront(elem)
+		return elem.Value.(*locationCacheEntry).location, true
+	}
+	return nil, false
+}
+
+func (lc *locationCache) put(name string, location *time.Location) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if _, ok := lc.items[name]; ok {
+		return
+	}
+	lc.items[name] = lc.order.PushFront(&locationCacheEntry{name: name, location: location})
+	for lc.order.Len() > maxCachedLocations {
+		oldest := lc.order.Back()
+		lc.order.Remove(oldest)
+		delete(lc.items, oldest.Value.(*locationCacheEntry).name)
+	}
+}
+
+func (lc *locationCache) len() int {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.order.Len()
+}
+
+// loadLocation loads a time zone by name, reusing zones loaded before, since time.LoadLocation
+// reads the zone database on every call.
+func (c *Conditions) loadLocation(value any) (*time.Location, error) {
+	name, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected time zone name, got %T", value)
+	}
+	if location, ok := c.locations.get(name); ok {
+		return location, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	c.locations.put(name, location)
+	return location, nil
+}
+
+// checkScheduleOperator evaluates the time window, cron and business hours operators against a time fact.
+func (c *Conditions) checkScheduleOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	t, err := toTimeFact(fact)
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case TIMEWINDOW:
+		window, err := c.parseTimeWindow(operator, conditionValue, timeWindow{end: 24 * 3600, location: c.location})
+		if err != nil {
+			return false, err
+		}
+		return window.contains(t), nil
+	case CRON:
+		expr, location := conditionValue, c.location
+		if spec, ok := conditionValue.(map[string]any); ok {
+			expr = spec["expr"]
+			if tz, exists := spec["tz"]; exists {
+				if location, err = c.loadLocation(tz); err != nil {
+					return false, err
+				}
+			}
+		}
+		exprStr, ok := expr.(string)
+		if !ok {
+			return false, fmt.Errorf("expected cron expression string for $cron operator, got %T", expr)
+		}
+		schedule, err := parseCron(exprStr)
+		if err != nil {
+			return false, err
+		}
+		return schedule.matches(t.In(location)), nil
+	case BUSINESSHOURS:
+		return c.checkBusinessHours(t, conditionValue)
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
+
+// checkBusinessHours checks that t falls into business hours, 09:00-17:00 Monday to Friday unless the
+// operand says otherwise, and not on a holiday of the calendar named by "calendar" or listed in "holidays".
+// A bool operand selects the defaults, with false matching outside business hours.
+func (c *Conditions) checkBusinessHours(t time.Time, conditionValue any) (bool, error) {
+	window := timeWindow{
+		start:    9 * 3600,
+		end:      17 * 3600,
+		days:     map[time.Weekday]bool{time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true, time.Friday: true},
+		location: c.location,
+	}
+
+	if expected, ok := conditionValue.(bool); ok {
+		return window.contains(t) == expected, nil
+	}
+
+	window, err := c.parseTimeWindow(BUSINESSHOURS, conditionValue, window)
+	if err != nil {
+		return false, err
+	}
+	if !window.contains(t) {
+		return false, nil
+	}
+
+	spec, _ := conditionValue.(map[string]any)
+	if name, exists := spec["calendar"]; exists {
+		calendar, ok := c.holidays[fmt.Sprint(name)]
+		if !ok {
+			return false, fmt.Errorf("unknown holiday calendar %v", name)
+		}
+		if calendar.Contains(t.In(window.location)) {
+			return false, nil
+		}
+	}
+	if dates, exists := spec["holidays"]; exists {
+		val := reflect.ValueOf(dates)
+		if val.Kind() != reflect.Slice {
+			return false, fmt.Errorf("expected list of holiday dates, got %T", dates)
+		}
+		list := make([]string, val.Len())
+		for i := range list {
+			list[i] = fmt.Sprint(val.Index(i).Interface())
+		}
+		calendar, err := NewHolidayCalendar(list...)
+		if err != nil {
+			return false, err
+		}
+		if calendar.Contains(t.In(window.location)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// cronSchedule is a parsed five-field cron expression: minute, hour, day of month, month and day of week.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+var cronDayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func parseCron(expr string) (cronSchedule, error) {
+	var schedule cronSchedule
+	if macro, ok := cronMacros[strings.TrimSpace(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return schedule, fmt.Errorf("expected 5 fields in cron expression %q, got %d", expr, len(fields))
+	}
+
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return schedule, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return schedule, err
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return schedule, err
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return schedule, err
+	}
+	if schedule.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return schedule, err
+	}
+	// 7 is an alias for Sunday.
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domStar = strings.HasPrefix(fields[2], "*")
+	schedule.dowStar = strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+// parseCronField parses lists, ranges, steps and names into a bit set of allowed values.
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in cron field %q", field)
+			}
+			step = n
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], names, min); err != nil {
+				return 0, fmt.Errorf("invalid cron field %q: %w", field, err)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = parseCronValue(bounds[1], names, min); err != nil {
+					return 0, fmt.Errorf("invalid cron field %q: %w", field, err)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("cron field %q out of range %d-%d", field, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, names []string, offset int) (int, error) {
+	lower := strings.ToLower(value)
+	for i, name := range names {
+		if lower == name {
+			return i + offset, nil
+		}
+	}
+	return strconv.Atoi(value)
+}
+
+func (s cronSchedule) matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 || s.hour&(1<<uint(t.Hour())) == 0 || s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	// As in cron, a restricted day of month and day of week match when either of them does.
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
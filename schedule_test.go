@@ -0,0 +1,159 @@
+package conditions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScheduleOperators(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	content := "# German public holidays\n2024-05-09 Ascension Day\n\n2024-05-20 Whit Monday\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	holidays, err := LoadHolidayCalendar(path)
+	if err != nil {
+		t.Fatalf("LoadHolidayCalendar() returned error: %v", err)
+	}
+
+	// Wednesday, 15 May 2024, 10:30 in Berlin
+	now := time.Date(2024, 5, 15, 10, 30, 0, 0, berlin)
+	cond := NewConditions(
+		WithClock(func() time.Time { return now }),
+		WithLocation(time.UTC),
+		WithHolidayCalendar("de", holidays),
+	)
+
+	window := map[string]any{
+		"start": "09:00",
+		"end":   "17:30",
+		"days":  []string{"mon", "tue", "wed", "thu", "fri"},
+		"tz":    "Europe/Berlin",
+	}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $timeWindow operator with injected clock",
+			condition: map[string]any{"{{$now}}": map[string]any{"$timeWindow": window}},
+			instance:  map[string]any{},
+			want:      true,
+		},
+		{
+			name:      "Test $timeWindow operator on weekend",
+			condition: map[string]any{"{{at}}": map[string]any{"$timeWindow": window}},
+			instance:  map[string]any{"at": time.Date(2024, 5, 18, 10, 0, 0, 0, berlin)},
+			want:      false,
+		},
+		{
+			name:      "Test $timeWindow operator in UTC outside Berlin window",
+			condition: map[string]any{"{{at}}": map[string]any{"$timeWindow": window}},
+			instance:  map[string]any{"at": time.Date(2024, 5, 15, 16, 0, 0, 0, time.UTC)},
+			want:      false,
+		},
+		{
+			name:      "Test $timeWindow operator after DST change",
+			condition: map[string]any{"{{at}}": map[string]any{"$timeWindow": map[string]any{"start": "09:00", "end": "10:00", "tz": "Europe/Berlin"}}},
+			instance:  map[string]any{"at": "2024-03-31T07:30:00Z"},
+			want:      true,
+		},
+		{
+			name:      "Test $timeWindow operator spanning midnight",
+			condition: map[string]any{"{{at}}": map[string]any{"$timeWindow": []string{"22:00", "06:00"}}},
+			instance:  map[string]any{"at": "2024-05-15T03:15:00Z"},
+			want:      true,
+		},
+		{
+			name:      "Test $cron operator every two hours on weekdays",
+			condition: map[string]any{"{{at}}": map[string]any{"$cron": "0 */2 * * 1-5"}},
+			instance:  map[string]any{"at": time.Date(2024, 5, 15, 14, 0, 0, 0, time.UTC)},
+			want:      true,
+		},
+		{
+			name:      "Test $cron operator on odd hour",
+			condition: map[string]any{"{{at}}": map[string]any{"$cron": "0 */2 * * 1-5"}},
+			instance:  map[string]any{"at": time.Date(2024, 5, 15, 15, 0, 0, 0, time.UTC)},
+			want:      false,
+		},
+		{
+			name:      "Test $cron operator with names and time zone",
+			condition: map[string]any{"{{at}}": map[string]any{"$cron": map[string]any{"expr": "30 10 * may wed", "tz": "Europe/Berlin"}}},
+			instance:  map[string]any{"at": now},
+			want:      true,
+		},
+		{
+			name:      "Test $cron operator with invalid expression",
+			condition: map[string]any{"{{at}}": map[string]any{"$cron": "61 * * * *"}},
+			instance:  map[string]any{"at": now},
+			want:      false,
+		},
+		{
+			name:      "Test $businessHours operator with defaults",
+			condition: map[string]any{"{{$now}}": map[string]any{"$businessHours": map[string]any{"tz": "Europe/Berlin"}}},
+			instance:  map[string]any{},
+			want:      true,
+		},
+		{
+			name:      "Test $businessHours operator on holiday from file",
+			condition: map[string]any{"{{at}}": map[string]any{"$businessHours": map[string]any{"tz": "Europe/Berlin", "calendar": "de"}}},
+			instance:  map[string]any{"at": time.Date(2024, 5, 20, 11, 0, 0, 0, berlin)},
+			want:      false,
+		},
+		{
+			name:      "Test $businessHours operator with inline holidays",
+			condition: map[string]any{"{{at}}": map[string]any{"$businessHours": map[string]any{"holidays": []string{"2024-05-15"}}}},
+			instance:  map[string]any{"at": time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC)},
+			want:      false,
+		},
+		{
+			name:      "Test $businessHours operator false outside hours",
+			condition: map[string]any{"{{at}}": map[string]any{"$businessHours": false}},
+			instance:  map[string]any{"at": time.Date(2024, 5, 15, 18, 0, 0, 0, time.UTC)},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocationCache(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	cond := NewConditions()
+
+	for _, name := range []string{"Europe/Berlin", "Asia/Tokyo", "Europe/Berlin"} {
+		if _, err := cond.loadLocation(name); err != nil {
+			t.Fatalf("loadLocation(%q) returned error: %v", name, err)
+		}
+	}
+	if _, err := cond.loadLocation("Mars/Olympus_Mons"); err == nil {
+		t.Fatalf("loadLocation() for an unknown zone returned no error")
+	}
+
+	if got := cond.locations.len(); got != 2 {
+		t.Fatalf("cache size = %d, want 2", got)
+	}
+	first, _ := cond.loadLocation("Europe/Berlin")
+	second, _ := cond.loadLocation("Europe/Berlin")
+	if first != second {
+		t.Errorf("expected Europe/Berlin to be loaded once")
+	}
+}
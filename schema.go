@@ -0,0 +1,474 @@
+package conditions
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// SchemaError describes why a fact does not conform to a $schema operand.
+type SchemaError struct {
+	Path    string // JSON Pointer to the failing value, "" for the fact itself
+	Keyword string // Failing schema keyword, like "type" or "required"
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("schema validation failed at %s: %s: %s", path, e.Keyword, e.Message)
+}
+
+// compiledSchema is a JSON Schema (draft 2020-12 subset) prepared for repeated validation.
+type compiledSchema struct {
+	boolean  *bool // Schemas may be plain true or false
+	types    []string
+	enum     []any
+	constant *any
+
+	minimum, maximum                   *float64
+	exclusiveMinimum, exclusiveMaximum *float64
+	minLength, maxLength               *int
+	pattern                            *regexp.Regexp
+
+	properties           map[string]*compiledSchema
+	required             []string
+	additionalProperties *compiledSchema
+
+	items              *compiledSchema
+	minItems, maxItems *int
+
+	allOf, anyOf, oneOf []*compiledSchema
+	not                 *compiledSchema
+}
+
+// schemaCache is a bounded LRU cache of compiled schemas keyed by their JSON text, safe for concurrent use.
+type schemaCache struct {
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type schemaCacheEntry struct {
+	key    string
+	schema *compiledSchema
+}
+
+const maxCachedSchemas = 128
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (sc *schemaCache) get(key string) (*compiledSchema, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if elem, ok := sc.items[key]; ok {
+		sc.order.MoveToFront(elem)
+		return elem.Value.(*schemaCacheEntry).schema, true
+	}
+	return nil, false
+}
+
+func (sc *schemaCache) put(key string, schema *compiledSchema) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if _, ok := sc.items[key]; ok {
+		return
+	}
+	sc.items[key] = sc.order.PushFront(&schemaCacheEntry{key: key, schema: schema})
+	for sc.order.Len() > maxCachedSchemas {
+		oldest := sc.order.Back()
+		sc.order.Remove(oldest)
+		delete(sc.items, oldest.Value.(*schemaCacheEntry).key)
+	}
+}
+
+// compileSchema compiles a schema given as a map or a JSON string, reusing earlier compilations.
+// Maps are keyed by their JSON encoding, which sorts keys, so a map changed in place is compiled again.
+func (c *Conditions) compileSchema(value any) (*compiledSchema, error) {
+	var raw []byte
+	if str, ok := value.(string); ok {
+		raw = []byte(str)
+	} else {
+		var err error
+		if raw, err = json.Marshal(value); err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+	}
+
+	key := string(raw)
+	if schema, ok := c.schemas.get(key); ok {
+		return schema, nil
+	}
+
+	// Decoding normalizes maps and numbers to the JSON types the compiler expects.
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	schema, err := c.buildSchema(decoded, "")
+	if err != nil {
+		return nil, err
+	}
+	c.schemas.put(key, schema)
+	return schema, nil
+}
+
+func (c *Conditions) buildSchema(value any, path string) (*compiledSchema, error) {
+	if b, ok := value.(bool); ok {
+		return &compiledSchema{boolean: &b}, nil
+	}
+	spec, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid schema at %s: expected object or bool, got %T", schemaPath(path), value)
+	}
+
+	schema := &compiledSchema{}
+	var err error
+
+	switch t := spec["type"].(type) {
+	case nil:
+	case string:
+		schema.types = []string{t}
+	case []any:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid schema at %s: type must be a string or list of strings", schemaPath(path))
+			}
+			schema.types = append(schema.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("invalid schema at %s: type must be a string or list of strings", schemaPath(path))
+	}
+
+	if enum, exists := spec["enum"]; exists {
+		if schema.enum, ok = enum.([]any); !ok {
+			return nil, fmt.Errorf("invalid schema at %s: enum must be a list", schemaPath(path))
+		}
+	}
+	if constant, exists := spec["const"]; exists {
+		schema.constant = &constant
+	}
+
+	for keyword, target := range map[string]**float64{
+		"minimum":          &schema.minimum,
+		"maximum":          &schema.maximum,
+		"exclusiveMinimum": &schema.exclusiveMinimum,
+		"exclusiveMaximum": &schema.exclusiveMaximum,
+	} {
+		if number, exists := spec[keyword]; exists {
+			f, ok := number.(float64)
+			if !ok {
+				return nil, fmt.Errorf("invalid schema at %s: %s must be a number", schemaPath(path), keyword)
+			}
+			*target = &f
+		}
+	}
+	for keyword, target := range map[string]**int{
+		"minLength": &schema.minLength,
+		"maxLength": &schema.maxLength,
+		"minItems":  &schema.minItems,
+		"maxItems":  &schema.maxItems,
+	} {
+		if number, exists := spec[keyword]; exists {
+			f, ok := number.(float64)
+			if !ok || f < 0 || f != math.Trunc(f) {
+				return nil, fmt.Errorf("invalid schema at %s: %s must be a non-negative integer", schemaPath(path), keyword)
+			}
+			n := int(f)
+			*target = &n
+		}
+	}
+
+	if pattern, exists := spec["pattern"]; exists {
+		str, ok := pattern.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid schema at %s: pattern must be a string", schemaPath(path))
+		}
+		if schema.pattern, err = c.compileCachedRegex(str); err != nil {
+			return nil, fmt.Errorf("invalid schema at %s: %w", schemaPath(path), err)
+		}
+	}
+
+	if properties, exists := spec["properties"]; exists {
+		props, ok := properties.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid schema at %s: properties must be an object", schemaPath(path))
+		}
+		schema.properties = make(map[string]*compiledSchema, len(props))
+		for name, sub := range props {
+			if schema.properties[name], err = c.buildSchema(sub, path+"/properties/"+name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if required, exists := spec["required"]; exists {
+		list, ok := required.([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid schema at %s: required must be a list", schemaPath(path))
+		}
+		for _, item := range list {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid schema at %s: required must list property names", schemaPath(path))
+			}
+			schema.required = append(schema.required, name)
+		}
+	}
+	if additional, exists := spec["additionalProperties"]; exists {
+		if schema.additionalProperties, err = c.buildSchema(additional, path+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if items, exists := spec["items"]; exists {
+		if schema.items, err = c.buildSchema(items, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if not, exists := spec["not"]; exists {
+		if schema.not, err = c.buildSchema(not, path+"/not"); err != nil {
+			return nil, err
+		}
+	}
+
+	for keyword, target := range map[string]*[]*compiledSchema{
+		"allOf": &schema.allOf,
+		"anyOf": &schema.anyOf,
+		"oneOf": &schema.oneOf,
+	} {
+		if list, exists := spec[keyword]; exists {
+			subs, ok := list.([]any)
+			if !ok || len(subs) == 0 {
+				return nil, fmt.Errorf("invalid schema at %s: %s must be a non-empty list", schemaPath(path), keyword)
+			}
+			for i, sub := range subs {
+				compiled, err := c.buildSchema(sub, fmt.Sprintf("%s/%s/%d", path, keyword, i))
+				if err != nil {
+					return nil, err
+				}
+				*target = append(*target, compiled)
+			}
+		}
+	}
+
+	return schema, nil
+}
+
+func schemaPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// jsonType classifies a decoded JSON value.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// validate checks a decoded JSON value, returning the first violation found.
+func (s *compiledSchema) validate(value any, path string) *SchemaError {
+	if s.boolean != nil {
+		if !*s.boolean {
+			return &SchemaError{Path: path, Keyword: "false", Message: "no value is allowed"}
+		}
+		return nil
+	}
+
+	actual := jsonType(value)
+	if len(s.types) > 0 {
+		matched := false
+		for _, t := range s.types {
+			if t == actual || t == "number" && actual == "integer" {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return &SchemaError{Path: path, Keyword: "type", Message: fmt.Sprintf("expected %s, got %s", strings.Join(s.types, " or "), actual)}
+		}
+	}
+
+	if s.enum != nil {
+		found := false
+		for _, option := range s.enum {
+			if reflect.DeepEqual(option, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return &SchemaError{Path: path, Keyword: "enum", Message: fmt.Sprintf("value %v is not one of %v", value, s.enum)}
+		}
+	}
+	if s.constant != nil && !reflect.DeepEqual(*s.constant, value) {
+		return &SchemaError{Path: path, Keyword: "const", Message: fmt.Sprintf("expected %v, got %v", *s.constant, value)}
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			return &SchemaError{Path: path, Keyword: "minimum", Message: fmt.Sprintf("%v is less than %v", v, *s.minimum)}
+		}
+		if s.maximum != nil && v > *s.maximum {
+			return &SchemaError{Path: path, Keyword: "maximum", Message: fmt.Sprintf("%v is greater than %v", v, *s.maximum)}
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			return &SchemaError{Path: path, Keyword: "exclusiveMinimum", Message: fmt.Sprintf("%v is not greater than %v", v, *s.exclusiveMinimum)}
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			return &SchemaError{Path: path, Keyword: "exclusiveMaximum", Message: fmt.Sprintf("%v is not less than %v", v, *s.exclusiveMaximum)}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			return &SchemaError{Path: path, Keyword: "minLength", Message: fmt.Sprintf("length %d is less than %d", length, *s.minLength)}
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			return &SchemaError{Path: path, Keyword: "maxLength", Message: fmt.Sprintf("length %d is greater than %d", length, *s.maxLength)}
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			return &SchemaError{Path: path, Keyword: "pattern", Message: fmt.Sprintf("%q does not match %q", v, s.pattern.String())}
+		}
+	case []any:
+		if s.minItems != nil && len(v) < *s.minItems {
+			return &SchemaError{Path: path, Keyword: "minItems", Message: fmt.Sprintf("%d items are fewer than %d", len(v), *s.minItems)}
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			return &SchemaError{Path: path, Keyword: "maxItems", Message: fmt.Sprintf("%d items are more than %d", len(v), *s.maxItems)}
+		}
+		if s.items != nil {
+			for i, item := range v {
+				if err := s.items.validate(item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				return &SchemaError{Path: path, Keyword: "required", Message: fmt.Sprintf("missing property %q", name)}
+			}
+		}
+		// Sorted keys make the reported violation deterministic.
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propertyPath := path + "/" + escapeJSONPointer(name)
+			if sub, ok := s.properties[name]; ok {
+				if err := sub.validate(v[name], propertyPath); err != nil {
+					return err
+				}
+			} else if s.additionalProperties != nil {
+				if err := s.additionalProperties.validate(v[name], propertyPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, sub := range s.allOf {
+		if err := sub.validate(value, path); err != nil {
+			return err
+		}
+	}
+	if s.anyOf != nil {
+		matched := false
+		for _, sub := range s.anyOf {
+			if sub.validate(value, path) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return &SchemaError{Path: path, Keyword: "anyOf", Message: "value matches none of the schemas"}
+		}
+	}
+	if s.oneOf != nil {
+		matches := 0
+		for _, sub := range s.oneOf {
+			if sub.validate(value, path) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return &SchemaError{Path: path, Keyword: "oneOf", Message: fmt.Sprintf("value matches %d schemas instead of exactly one", matches)}
+		}
+	}
+	if s.not != nil && s.not.validate(value, path) == nil {
+		return &SchemaError{Path: path, Keyword: "not", Message: "value matches the disallowed schema"}
+	}
+	return nil
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// toJSONValue converts a Go value into its decoded JSON form, so that structs, typed slices and
+// maps are validated the way they would be serialized.
+func toJSONValue(value any) (any, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert instance value to JSON: %w", err)
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("cannot convert instance value to JSON: %w", err)
+	}
+	return decoded, nil
+}
+
+// checkSchema validates the fact against a JSON Schema operand. A violation is returned as a *SchemaError.
+func (c *Conditions) checkSchema(fact any, conditionValue any) (bool, error) {
+	schema, err := c.compileSchema(conditionValue)
+	if err != nil {
+		return false, err
+	}
+	value, err := toJSONValue(fact)
+	if err != nil {
+		return false, err
+	}
+	if validationErr := schema.validate(value, ""); validationErr != nil {
+		return false, validationErr
+	}
+	return true, nil
+}
@@ -0,0 +1,195 @@
+package conditions
+
+import (
+	"errors"
+	"testing"
+)
+
+type schemaAddress struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip"`
+}
+
+func TestSchemaOperator(t *testing.T) {
+	cond := NewConditions()
+
+	schema := map[string]any{
+		"type":     "object",
+		"required": []string{"name", "age"},
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string", "minLength": 1, "maxLength": 20},
+			"age":  map[string]any{"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+			"role": map[string]any{"enum": []string{"admin", "user"}},
+			"tags": map[string]any{
+				"type":     "array",
+				"items":    map[string]any{"type": "string", "pattern": "^[a-z]+$"},
+				"maxItems": 3,
+			},
+			"address": map[string]any{
+				"type":     "object",
+				"required": []string{"zip"},
+				"properties": map[string]any{
+					"zip": map[string]any{"type": "string", "pattern": "^[0-9]{5}$"},
+				},
+			},
+			"contact": map[string]any{
+				"oneOf": []any{
+					map[string]any{"type": "string", "pattern": "@"},
+					map[string]any{"type": "integer"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		instance    any
+		want        bool
+		wantKeyword string
+	}{
+		{
+			name: "Test $schema operator with valid object",
+			instance: map[string]any{"user": map[string]any{
+				"name": "Jane", "age": 34, "role": "admin", "tags": []string{"vip"},
+				"address": schemaAddress{Street: "Main", Zip: "10115"}, "contact": "jane@example.com",
+			}},
+			want: true,
+		},
+		{
+			name:        "Test $schema operator with missing required property",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane"}},
+			wantKeyword: "required",
+		},
+		{
+			name:        "Test $schema operator with wrong type",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane", "age": "34"}},
+			wantKeyword: "type",
+		},
+		{
+			name:        "Test $schema operator with non-integer number",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane", "age": 34.5}},
+			wantKeyword: "type",
+		},
+		{
+			name:        "Test $schema operator with value outside enum",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane", "age": 34, "role": "root"}},
+			wantKeyword: "enum",
+		},
+		{
+			name:        "Test $schema operator with nested struct pattern mismatch",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane", "age": 34, "address": schemaAddress{Zip: "ABC"}}},
+			wantKeyword: "pattern",
+		},
+		{
+			name:        "Test $schema operator with invalid array item",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane", "age": 34, "tags": []string{"ok", "NOT"}}},
+			wantKeyword: "pattern",
+		},
+		{
+			name:        "Test $schema operator with oneOf mismatch",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane", "age": 34, "contact": true}},
+			wantKeyword: "oneOf",
+		},
+		{
+			name:        "Test $schema operator with exclusive maximum",
+			instance:    map[string]any{"user": map[string]any{"name": "Jane", "age": 150}},
+			wantKeyword: "exclusiveMaximum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := map[string]any{"{{user}}": map[string]any{"$schema": schema}}
+
+			if got := cond.Check(tt.instance, condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+
+			_, err := cond.Evaluate(tt.instance, condition)
+			var schemaErr *SchemaError
+			if tt.wantKeyword == "" {
+				if err != nil {
+					t.Errorf("Evaluate() returned unexpected error: %v", err)
+				}
+			} else if !errors.As(err, &schemaErr) || schemaErr.Keyword != tt.wantKeyword {
+				t.Errorf("Evaluate() error = %v, want schema error for keyword %s", err, tt.wantKeyword)
+			}
+		})
+	}
+}
+
+func TestSchemaOperatorCachesAndRejectsInvalidSchemas(t *testing.T) {
+	cond := NewConditions()
+
+	schema := map[string]any{"minLength": 2, "type": "string"}
+	for _, operand := range []any{`{"type": "string", "minLength": 2}`, schema} {
+		first, err := cond.compileSchema(operand)
+		if err != nil {
+			t.Fatalf("compileSchema() returned error: %v", err)
+		}
+		second, err := cond.compileSchema(operand)
+		if err != nil {
+			t.Fatalf("compileSchema() returned error: %v", err)
+		}
+		if first != second {
+			t.Errorf("expected %T schema to be compiled once", operand)
+		}
+	}
+
+	_, err := cond.Evaluate(map[string]any{"name": "x"}, map[string]any{"{{name}}": map[string]any{"$schema": map[string]any{"minLength": -1}}})
+	if err == nil {
+		t.Errorf("expected error for invalid schema")
+	}
+}
+
+func TestSchemaViolationsDoNotOverrideResult(t *testing.T) {
+	cond := NewConditions()
+	instance := map[string]any{"name": "x", "age": 30}
+	schema := map[string]any{"type": "string", "minLength": 2}
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      bool
+	}{
+		{
+			name: "Test $or with a matching branch",
+			condition: map[string]any{"$or": []map[string]any{
+				{"{{name}}": map[string]any{"$schema": schema}},
+				{"{{age}}": map[string]any{"$gte": 18}},
+			}},
+			want: true,
+		},
+		{
+			name:      "Test $not around a failing schema",
+			condition: map[string]any{"$not": []map[string]any{{"{{name}}": map[string]any{"$schema": schema}}}},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+			if got, err := cond.Evaluate(instance, tt.condition); got != tt.want || err != nil {
+				t.Errorf("Evaluate() for %s = %v, %v, want %v, nil", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaOperatorSeesChangedSchemaMaps(t *testing.T) {
+	cond := NewConditions()
+	schema := map[string]any{"type": "string"}
+	condition := map[string]any{"{{name}}": map[string]any{"$schema": schema}}
+	instance := map[string]any{"name": "x"}
+
+	if !cond.Check(instance, condition) {
+		t.Fatalf("Check() = false, want true")
+	}
+	schema["type"] = "number"
+	if cond.Check(instance, condition) {
+		t.Errorf("Check() after changing the schema = true, want false")
+	}
+}
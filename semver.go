@@ -0,0 +1,424 @@
+package conditions
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// semVersion is a semantic version as defined by SemVer 2.0.
+type semVersion struct {
+	major, minor, patch uint64
+	prerelease          []string
+	build               string
+}
+
+// parseSemVersion parses versions like "1.2.3", "v1.10.0-beta.1+build.5".
+// Missing minor and patch numbers default to zero, so "1.9" is read as "1.9.0".
+func parseSemVersion(value string) (semVersion, error) {
+	parts, prerelease, build, err := splitSemVersion(value)
+	if err != nil {
+		return semVersion{}, err
+	}
+
+	var v semVersion
+	numbers := []*uint64{&v.major, &v.minor, &v.patch}
+	for i, part := range parts {
+		n, err := parseSemNumber(part)
+		if err != nil {
+			return semVersion{}, fmt.Errorf("invalid version %q: %w", value, err)
+		}
+		*numbers[i] = n
+	}
+	v.prerelease = prerelease
+	v.build = build
+	return v, nil
+}
+
+// splitSemVersion splits a version into its dot separated numbers, prerelease identifiers and build metadata.
+func splitSemVersion(value string) ([]string, []string, string, error) {
+	str := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(value), "v"), "=")
+	if str == "" {
+		return nil, nil, "", fmt.Errorf("empty version")
+	}
+
+	var build string
+	if i := strings.Index(str, "+"); i >= 0 {
+		str, build = str[:i], str[i+1:]
+	}
+	var prerelease []string
+	if i := strings.Index(str, "-"); i >= 0 {
+		prerelease = strings.Split(str[i+1:], ".")
+		str = str[:i]
+		for _, id := range prerelease {
+			if id == "" {
+				return nil, nil, "", fmt.Errorf("invalid prerelease in version %q", value)
+			}
+		}
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > 3 {
+		return nil, nil, "", fmt.Errorf("invalid version %q", value)
+	}
+	return parts, prerelease, build, nil
+}
+
+func parseSemNumber(part string) (uint64, error) {
+	if part == "" || len(part) > 1 && part[0] == '0' {
+		return 0, fmt.Errorf("invalid numeric identifier %q", part)
+	}
+	return strconv.ParseUint(part, 10, 64)
+}
+
+// compareSemVersions orders versions by SemVer 2.0 precedence. Build metadata is ignored.
+func compareSemVersions(a, b semVersion) int {
+	for _, pair := range [][2]uint64{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A version without prerelease has higher precedence than the same version with one.
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		if result := comparePrereleaseIdentifiers(a.prerelease[i], b.prerelease[i]); result != 0 {
+			return result
+		}
+	}
+	return compareInts(len(a.prerelease), len(b.prerelease))
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers numerically and others lexically,
+// with numeric identifiers ordered before alphanumeric ones.
+func comparePrereleaseIdentifiers(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		if na < nb {
+			return -1
+		} else if na > nb {
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// semComparator is a single primitive constraint like ">=1.2.0".
+type semComparator struct {
+	op      string
+	version semVersion
+	// explicitPrerelease is set when the user wrote a prerelease, which lets prereleases
+	// of the same major.minor.patch satisfy the range, as in npm.
+	explicitPrerelease bool
+}
+
+func (sc semComparator) matches(v semVersion) bool {
+	result := compareSemVersions(v, sc.version)
+	switch sc.op {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	default:
+		return result == 0
+	}
+}
+
+// semRange is a union of comparator sets, each being an intersection of comparators.
+type semRange [][]semComparator
+
+// parseSemRange parses npm and Cargo style ranges: comparators (<, <=, >, >=, =), caret (^1.2),
+// tilde (~1.2.3), wildcards (1.x, *), hyphen ranges (1.2 - 2.3.4), combined with spaces or commas
+// for intersection and || for union.
+func parseSemRange(value string) (semRange, error) {
+	var r semRange
+	for _, alternative := range strings.Split(value, "||") {
+		set, err := parseSemComparatorSet(alternative)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", value, err)
+		}
+		r = append(r, set)
+	}
+	return r, nil
+}
+
+func parseSemComparatorSet(value string) ([]semComparator, error) {
+	fields := strings.Fields(strings.ReplaceAll(value, ",", " "))
+
+	// Hyphen ranges: "1.2.3 - 2.3.4".
+	if len(fields) == 3 && fields[1] == "-" {
+		lower, err := parsePartialVersion(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		upper, err := parsePartialVersion(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		set := []semComparator{lower.comparator(">=")}
+		if upper.wildcard {
+			return set, nil
+		}
+		if upper.missing > 0 {
+			return append(set, upper.next().comparator("<")), nil
+		}
+		return append(set, upper.comparator("<=")), nil
+	}
+
+	var set []semComparator
+	for i := 0; i < len(fields); i++ {
+		token := fields[i]
+		// Operators separated from their version by a space, like ">= 1.2.0".
+		if strings.Trim(token, "<>=^~") == "" && i+1 < len(fields) {
+			i++
+			token += fields[i]
+		}
+		comparators, err := parseSemComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	if len(set) == 0 {
+		// An empty range matches any version.
+		set = append(set, semComparator{op: ">="})
+	}
+	return set, nil
+}
+
+// partialVersion is a version in a range where trailing numbers may be missing or wildcards.
+type partialVersion struct {
+	version  semVersion
+	missing  int  // Number of missing or wildcard components among major, minor and patch
+	wildcard bool // The whole version is a wildcard
+}
+
+func parsePartialVersion(value string) (partialVersion, error) {
+	parts, prerelease, build, err := splitSemVersion(value)
+	if err != nil {
+		return partialVersion{}, err
+	}
+
+	var p partialVersion
+	numbers := []*uint64{&p.version.major, &p.version.minor, &p.version.patch}
+	p.missing = 3 - len(parts)
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			p.missing = 3 - i
+			break
+		}
+		n, err := parseSemNumber(part)
+		if err != nil {
+			return partialVersion{}, fmt.Errorf("invalid version %q: %w", value, err)
+		}
+		*numbers[i] = n
+	}
+	p.wildcard = p.missing == 3
+	if p.missing == 0 {
+		p.version.prerelease = prerelease
+		p.version.build = build
+	}
+	return p, nil
+}
+
+func (p partialVersion) comparator(op string) semComparator {
+	return semComparator{op: op, version: p.version, explicitPrerelease: len(p.version.prerelease) > 0}
+}
+
+// next returns the smallest version above every version matched by the partial version,
+// as a "-0" prerelease so that prereleases of that version are excluded too.
+func (p partialVersion) next() partialVersion {
+	v := semVersion{major: p.version.major, minor: p.version.minor, patch: p.version.patch, prerelease: []string{"0"}}
+	switch p.missing {
+	case 2:
+		v.major, v.minor, v.patch = v.major+1, 0, 0
+	case 1:
+		v.minor, v.patch = v.minor+1, 0
+	default:
+		v.patch++
+	}
+	return partialVersion{version: v}
+}
+
+func lowestPrerelease(v semVersion) semVersion {
+	v.prerelease = []string{"0"}
+	return v
+}
+
+func parseSemComparator(token string) ([]semComparator, error) {
+	op := token[:len(token)-len(strings.TrimLeft(token, "<>=^~"))]
+	p, err := parsePartialVersion(token[len(op):])
+	if err != nil {
+		return nil, err
+	}
+
+	if p.wildcard {
+		if op == "<" || op == ">" {
+			// Nothing is below or above every version.
+			return []semComparator{{op: "<", version: semVersion{prerelease: []string{"0"}}}}, nil
+		}
+		return []semComparator{{op: ">="}}, nil
+	}
+
+	switch op {
+	case "", "=":
+		if p.missing == 0 {
+			return []semComparator{p.comparator("=")}, nil
+		}
+		return []semComparator{p.comparator(">="), p.next().comparator("<")}, nil
+	case "~", "~>":
+		upper := p
+		if p.missing == 0 {
+			upper.missing = 1
+		}
+		return []semComparator{p.comparator(">="), upper.next().comparator("<")}, nil
+	case "^":
+		upper := p
+		switch {
+		case p.version.major > 0 || p.missing >= 2:
+			upper.missing = 2
+		case p.version.minor > 0 || p.missing == 1:
+			upper.missing = 1
+		default:
+			upper.missing = 0
+		}
+		return []semComparator{p.comparator(">="), upper.next().comparator("<")}, nil
+	case ">":
+		if p.missing > 0 {
+			return []semComparator{{op: ">=", version: p.next().version}}, nil
+		}
+		return []semComparator{p.comparator(">")}, nil
+	case ">=":
+		return []semComparator{p.comparator(">=")}, nil
+	case "<":
+		if p.missing > 0 {
+			return []semComparator{{op: "<", version: lowestPrerelease(p.version)}}, nil
+		}
+		return []semComparator{p.comparator("<")}, nil
+	case "<=":
+		if p.missing > 0 {
+			return []semComparator{p.next().comparator("<")}, nil
+		}
+		return []semComparator{p.comparator("<=")}, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+}
+
+// satisfies reports whether the version is in the range. A prerelease version only satisfies a
+// comparator set that names a prerelease of the same major.minor.patch, as in npm.
+func (r semRange) satisfies(v semVersion) bool {
+	for _, set := range r {
+		if setSatisfies(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func setSatisfies(set []semComparator, v semVersion) bool {
+	for _, comparator := range set {
+		if !comparator.matches(v) {
+			return false
+		}
+	}
+	if len(v.prerelease) == 0 {
+		return true
+	}
+	for _, comparator := range set {
+		cv := comparator.version
+		if comparator.explicitPrerelease && cv.major == v.major && cv.minor == v.minor && cv.patch == v.patch {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSemverOperator evaluates the semantic version operators against a version string fact.
+func (c *Conditions) checkSemverOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	str, ok := fact.(string)
+	if !ok {
+		return false, fmt.Errorf("expected version string for instance value, got %T", fact)
+	}
+	version, err := parseSemVersion(str)
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case SEMVERGT, SEMVERLT:
+		operand, ok := conditionValue.(string)
+		if !ok {
+			return false, fmt.Errorf("expected version string for %s operator, got %T", operator, conditionValue)
+		}
+		other, err := parseSemVersion(operand)
+		if err != nil {
+			return false, err
+		}
+		if operator == SEMVERGT {
+			return compareSemVersions(version, other) > 0, nil
+		}
+		return compareSemVersions(version, other) < 0, nil
+	case SEMVERBETWEEN:
+		val := reflect.ValueOf(conditionValue)
+		if val.Kind() != reflect.Slice || val.Len() != 2 {
+			return false, fmt.Errorf("expected condition to be a slice with exactly two elements")
+		}
+		var bounds [2]semVersion
+		for i := range bounds {
+			bound, ok := val.Index(i).Interface().(string)
+			if !ok {
+				return false, fmt.Errorf("expected version string bounds for %s operator", operator)
+			}
+			if bounds[i], err = parseSemVersion(bound); err != nil {
+				return false, err
+			}
+		}
+		return compareSemVersions(version, bounds[0]) >= 0 && compareSemVersions(version, bounds[1]) <= 0, nil
+	case SEMVERSATISFIES:
+		operand, ok := conditionValue.(string)
+		if !ok {
+			return false, fmt.Errorf("expected range string for %s operator, got %T", operator, conditionValue)
+		}
+		r, err := parseSemRange(operand)
+		if err != nil {
+			return false, err
+		}
+		return r.satisfies(version), nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
@@ -0,0 +1,119 @@
+package conditions
+
+import (
+	"testing"
+)
+
+func TestSemverOperators(t *testing.T) {
+	cond := NewConditions()
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $semverGt operator compares numerically",
+			condition: map[string]any{"{{app.version}}": map[string]any{"$semverGt": "1.9.3"}},
+			instance:  map[string]any{"app": map[string]any{"version": "1.10.0"}},
+			want:      true,
+		},
+		{
+			name:      "Test $semverLt operator with prerelease",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverLt": "2.0.0"}},
+			instance:  map[string]any{"version": "2.0.0-rc.1"},
+			want:      true,
+		},
+		{
+			name:      "Test $semverGt operator ignores build metadata",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverGt": "1.0.0+build.1"}},
+			instance:  map[string]any{"version": "1.0.0+build.2"},
+			want:      false,
+		},
+		{
+			name:      "Test $semverBetween operator true",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverBetween": []string{"1.2.0", "1.10.0"}}},
+			instance:  map[string]any{"version": "v1.9"},
+			want:      true,
+		},
+		{
+			name:      "Test $semverSatisfies operator with caret",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverSatisfies": "^1.2 || >=2.0.0-beta"}},
+			instance:  map[string]any{"version": "1.9.3"},
+			want:      true,
+		},
+		{
+			name:      "Test $semverSatisfies operator with prerelease of named version",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverSatisfies": "^1.2 || >=2.0.0-beta"}},
+			instance:  map[string]any{"version": "2.0.0-beta.2"},
+			want:      true,
+		},
+		{
+			name:      "Test $semverSatisfies operator excludes other prereleases",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverSatisfies": "^1.2 || >=2.0.0-beta"}},
+			instance:  map[string]any{"version": "2.1.0-alpha"},
+			want:      false,
+		},
+		{
+			name:      "Test $semverSatisfies operator with Cargo style comparators",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverSatisfies": ">= 1.2, < 1.5"}},
+			instance:  map[string]any{"version": "1.5.0"},
+			want:      false,
+		},
+		{
+			name:      "Test $semverSatisfies operator with tilde",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverSatisfies": "~1.2.3"}},
+			instance:  map[string]any{"version": "1.2.9"},
+			want:      true,
+		},
+		{
+			name:      "Test $semverSatisfies operator with hyphen range",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverSatisfies": "1.2 - 2.3"}},
+			instance:  map[string]any{"version": "2.3.7"},
+			want:      true,
+		},
+		{
+			name:      "Test $semverSatisfies operator with x-range",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverSatisfies": "3.x"}},
+			instance:  map[string]any{"version": "4.0.0"},
+			want:      false,
+		},
+		{
+			name:      "Test $semverGt operator with invalid version",
+			condition: map[string]any{"{{version}}": map[string]any{"$semverGt": "1.0.0"}},
+			instance:  map[string]any{"version": "1.02.0"},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSemVersionsPrecedence(t *testing.T) {
+	// Ordered by increasing precedence, as in the SemVer 2.0 specification.
+	versions := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0",
+	}
+
+	for i := 0; i < len(versions)-1; i++ {
+		a, err := parseSemVersion(versions[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := parseSemVersion(versions[i+1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := compareSemVersions(a, b); got != -1 {
+			t.Errorf("compareSemVersions(%s, %s) = %d, want -1", versions[i], versions[i+1], got)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package conditions
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// sortOrder is the operand of $sorted: the direction and an optional path of the sort key.
+type sortOrder struct {
+	descending bool
+	by         string
+}
+
+// parseSortOrder reads "asc", "desc", true, or a map like {"order": "desc", "by": "createdAt"}.
+func parseSortOrder(operand any) (sortOrder, error) {
+	switch v := operand.(type) {
+	case bool:
+		if !v {
+			return sortOrder{}, fmt.Errorf("expected true, \"asc\", \"desc\" or a map for $sorted operator, got false")
+		}
+		return sortOrder{}, nil
+	case string:
+		return parseSortDirection(sortOrder{}, v)
+	case map[string]any:
+		order := sortOrder{}
+		if by, exists := v["by"]; exists {
+			path, ok := by.(string)
+			if !ok || path == "" {
+				return sortOrder{}, fmt.Errorf("expected path for $sorted by, got %v", by)
+			}
+			order.by = path
+		}
+		if direction, exists := v["order"]; exists {
+			name, ok := direction.(string)
+			if !ok {
+				return sortOrder{}, fmt.Errorf("expected \"asc\" or \"desc\" for $sorted order, got %v", direction)
+			}
+			return parseSortDirection(order, name)
+		}
+		return order, nil
+	default:
+		return sortOrder{}, fmt.Errorf("expected true, \"asc\", \"desc\" or a map for $sorted operator, got %T", operand)
+	}
+}
+
+func parseSortDirection(order sortOrder, direction string) (sortOrder, error) {
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "asc":
+		return order, nil
+	case "desc":
+		order.descending = true
+		return order, nil
+	default:
+		return sortOrder{}, fmt.Errorf("expected \"asc\" or \"desc\" for $sorted operator, got %q", direction)
+	}
+}
+
+// distinctValues returns the elements without duplicates, comparing them as $eq does. Elements are
+// bucketed by their distinct keys, so each is compared only with the elements sharing a key, and with
+// the elements without keys, which are compared with every element.
+func (c *Conditions) distinctValues(elements []any) []any {
+	var distinct, unkeyed []any
+	buckets := make(map[any][]any)
+
+	for _, element := range elements {
+		keys := c.distinctKeys(element)
+		if c.containsValue(unkeyed, element) || (keys == nil && c.containsValue(distinct, element)) {
+			continue
+		}
+		duplicate := false
+		for _, key := range keys {
+			if duplicate = c.containsValue(buckets[key], element); duplicate {
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+
+		distinct = append(distinct, element)
+		if keys == nil {
+			unkeyed = append(unkeyed, element)
+		}
+		for _, key := range keys {
+			buckets[key] = append(buckets[key], element)
+		}
+	}
+	return distinct
+}
+
+// Keys of numbers and times, typed so that they never collide with the values used as their own keys.
+type (
+	exactKey string  // Exact value of an integer or decimal
+	floatKey float64 // Value of a float, or a float an exact value equals
+	timeKey  struct {
+		sec  int64
+		nsec int
+	}
+)
+
+// distinctKeys returns hashable keys of a value, such that two values $eq finds equal share at least
+// one key. Values with their own notion of equality, like structs, slices and *big.Float, have none.
+func (c *Conditions) distinctKeys(value any) []any {
+	keys := valueKeys(value)
+	if keys == nil || c.coercion == CoercionNone {
+		return keys
+	}
+
+	switch v := value.(type) {
+	case bool:
+		// JavaScript compares bools with other values as 0 and 1
+		if c.coercion == CoercionJavaScript {
+			flag := 0
+			if v {
+				flag = 1
+			}
+			keys = append(keys, valueKeys(flag)...)
+		}
+	case string:
+		// Strings equal the numbers, bools and times they convert to
+		for _, other := range []any{0, true, time.Time{}} {
+			if coerced := c.coerceTowards(v, other); coerced != value {
+				keys = append(keys, valueKeys(coerced)...)
+			}
+		}
+	}
+	return keys
+}
+
+// valueKeys returns the keys of a value without coercion: the instant of a time, the keys of a number,
+// and the value itself for other values of basic types.
+func valueKeys(value any) []any {
+	if t, ok := value.(time.Time); ok {
+		return []any{timeKey{sec: t.Unix(), nsec: t.Nanosecond()}}
+	}
+	if isNumber(value) {
+		return numberKeys(value)
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Invalid, reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return []any{value}
+	default:
+		return nil
+	}
+}
+
+// numberKeys returns the keys of a number, following compareNumbers: a float is keyed by its value and an
+// exact number by its value and the floats it equals, which for a fraction are its roundings to float32
+// and float64 precision. NaN equals nothing and a *big.Float may have any precision, so they have none.
+func numberKeys(value any) []any {
+	n, ok := toNumber(value)
+	if _, isBigFloat := value.(*big.Float); !ok || isBigFloat || n.isNaN() {
+		return nil
+	}
+	if n.rat == nil || n.prec > 0 {
+		return []any{floatKey(n.toFloat64())}
+	}
+
+	keys := []any{exactKey(n.rat.RatString())}
+	if n.rat.IsInt() {
+		if f, exact := n.rat.Float64(); exact {
+			keys = append(keys, floatKey(f))
+		}
+		return keys
+	}
+	for _, prec := range []uint{24, 53} {
+		f, _ := roundRat(n.rat, prec).Float64()
+		keys = append(keys, floatKey(f))
+	}
+	return keys
+}
+
+// checkSequenceOperator handles $unique, $distinctCount and $sorted. Elements are compared as $eq and
+// $lt compare them, so coercion, exact numbers and collation apply.
+func (c *Conditions) checkSequenceOperator(operator CommonOperatorsEnum, key string, fact any, conditionValue any) (bool, error) {
+	elements, ok := listElements(fact)
+	if !ok {
+		return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
+	}
+
+	switch operator {
+	case UNIQUE:
+		expected, ok := conditionValue.(bool)
+		if !ok {
+			return false, fmt.Errorf("expected bool for $unique operator, got %T", conditionValue)
+		}
+		return (len(c.distinctValues(elements)) == len(elements)) == expected, nil
+	case DISTINCTCOUNT:
+		return c.checkDerivedNumber(DISTINCTCOUNT, len(c.distinctValues(elements)), conditionValue)
+	case SORTED:
+		order, err := parseSortOrder(conditionValue)
+		if err != nil {
+			return false, err
+		}
+		for i := 1; i < len(elements); i++ {
+			previous, current := elements[i-1], elements[i]
+			if order.by != "" {
+				previous, current = c.getValueByTemplate(order.by, previous), c.getValueByTemplate(order.by, current)
+			}
+			result, err := c.compare(previous, current)
+			if err != nil {
+				return false, fmt.Errorf("%s[%d]: %w", key, i, err)
+			}
+			if order.descending && result < 0 || !order.descending && result > 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
@@ -0,0 +1,158 @@
+package conditions
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSequenceOperators(t *testing.T) {
+	cond := NewConditions()
+	folding := NewConditions(WithCaseFolding())
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	instance := map[string]any{
+		"items": []any{
+			map[string]any{"sku": "A-1", "createdAt": start},
+			map[string]any{"sku": "B-2", "createdAt": start.Add(time.Hour)},
+			map[string]any{"sku": "A-1", "createdAt": start.Add(2 * time.Hour)},
+		},
+		"emails":  []string{"ann@example.com", "Ann@Example.com", "bob@example.com"},
+		"scores":  []any{3, 3.0, 7, 9},
+		"ranking": []int{9, 7, 7, 2},
+		"mixed":   []any{1, "two"},
+	}
+
+	tests := []struct {
+		name      string
+		cond      *Conditions
+		condition map[string]any
+		want      bool
+	}{
+		{
+			name:      "Test $unique operator over wildcard path",
+			cond:      cond,
+			condition: map[string]any{"{{items.*.sku}}": map[string]any{"$unique": true}},
+			want:      false,
+		},
+		{
+			name:      "Test $unique operator false finds duplicates",
+			cond:      cond,
+			condition: map[string]any{"{{items.*.sku}}": map[string]any{"$unique": false}},
+			want:      true,
+		},
+		{
+			name:      "Test $unique operator is case-sensitive by default",
+			cond:      cond,
+			condition: map[string]any{"{{emails}}": map[string]any{"$unique": true}},
+			want:      true,
+		},
+		{
+			name:      "Test $unique operator with case folding",
+			cond:      folding,
+			condition: map[string]any{"{{emails}}": map[string]any{"$unique": true}},
+			want:      false,
+		},
+		{
+			name:      "Test $distinctCount operator with exact number",
+			cond:      cond,
+			condition: map[string]any{"{{items.*.sku}}": map[string]any{"$distinctCount": 2}},
+			want:      true,
+		},
+		{
+			name:      "Test $distinctCount operator with nested operator",
+			cond:      folding,
+			condition: map[string]any{"{{emails}}": map[string]any{"$distinctCount": map[string]any{"$lt": 3}}},
+			want:      true,
+		},
+		{
+			name:      "Test $sorted operator ascending by path",
+			cond:      cond,
+			condition: map[string]any{"{{items}}": map[string]any{"$sorted": map[string]any{"by": "createdAt"}}},
+			want:      true,
+		},
+		{
+			name:      "Test $sorted operator descending by path",
+			cond:      cond,
+			condition: map[string]any{"{{items}}": map[string]any{"$sorted": map[string]any{"by": "{{createdAt}}", "order": "desc"}}},
+			want:      false,
+		},
+		{
+			name:      "Test $sorted operator descending with ties",
+			cond:      cond,
+			condition: map[string]any{"{{ranking}}": map[string]any{"$sorted": "desc"}},
+			want:      true,
+		},
+		{
+			name:      "Test $sorted operator with mixed numbers",
+			cond:      cond,
+			condition: map[string]any{"{{scores}}": map[string]any{"$sorted": true}},
+			want:      true,
+		},
+		{
+			name:      "Test $sorted operator with incomparable elements",
+			cond:      cond,
+			condition: map[string]any{"{{mixed}}": map[string]any{"$sorted": "asc"}},
+			want:      false,
+		},
+		{
+			name:      "Test $sorted operator with invalid direction",
+			cond:      cond,
+			condition: map[string]any{"{{ranking}}": map[string]any{"$sorted": "up"}},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistinctValuesMatchesPairwiseComparison(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	elements := []any{
+		1, int64(1), uint8(1), 1.0, float32(1), json.Number("1"), big.NewInt(1), big.NewFloat(1), "1", "1.0", true, "true",
+		float32(0.3), json.Number("0.3"), 0.1, float32(0.1), json.Number("0.1"), big.NewRat(1, 10), "0.1", "0.10",
+		int64(1 << 53), int64(1<<53 + 1), float64(1 << 53), math.NaN(), math.Inf(1), "inf",
+		at, at.In(berlin), at.Format(time.RFC3339), "", 0, false, nil, 90 * time.Second,
+		[]int{1}, []int{1}, map[string]any{"a": 1}, map[string]any{"a": 1}, struct{ X int }{1},
+	}
+
+	for _, policy := range []CoercionPolicy{CoercionNone, CoercionLenient, CoercionJavaScript} {
+		cond := NewConditions(WithCoercion(policy))
+		var want []any
+		for _, element := range elements {
+			if !cond.containsValue(want, element) {
+				want = append(want, element)
+			}
+		}
+
+		got := cond.distinctValues(elements)
+		if len(got) != len(want) {
+			t.Fatalf("distinctValues() with policy %d = %v, want %v", policy, got, want)
+		}
+		for i := range want {
+			if !reflect.DeepEqual(got[i], want[i]) && !(isNaN(got[i]) && isNaN(want[i])) {
+				t.Errorf("distinctValues() with policy %d [%d] = %v, want %v", policy, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestDistinctValuesOfLongList(t *testing.T) {
+	cond := NewConditions()
+	elements := make([]any, 100_000)
+	for i := range elements {
+		elements[i] = i % 50_000
+	}
+	if got := len(cond.distinctValues(elements)); got != 50_000 {
+		t.Errorf("distinctValues() returned %d values, want 50000", got)
+	}
+}
@@ -0,0 +1,232 @@
+package conditions
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// WithLegacyListOperators restores the original semantics of $every and $noone with a list operand:
+// $every matches when every operand value appears in the fact, and $noone when no operand value does
+// but the fact has some other element. With a single value, $every matches when some element equals it.
+// Values are compared with reflect.DeepEqual.
+func WithLegacyListOperators() Option {
+	return func(c *Conditions) {
+		c.legacyListOperators = true
+	}
+}
+
+// listElements returns the elements of a slice or array.
+func listElements(value any) ([]any, bool) {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, false
+	}
+	elements := make([]any, val.Len())
+	for i := range elements {
+		elements[i] = val.Index(i).Interface()
+	}
+	return elements, true
+}
+
+// containsValue reports whether the list holds a value equal to value, as $eq would compare them.
+func (c *Conditions) containsValue(list []any, value any) bool {
+	for _, item := range list {
+		if c.valuesEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkListOperator handles $some, $every and $noone. They quantify over the elements of the fact:
+// some, every or no element is one of the operand values, or satisfies the operand sub-condition.
+// A single operand value is a list of one.
+func (c *Conditions) checkListOperator(operator CommonOperatorsEnum, key string, fact any, conditionValue any, instance any) (bool, error) {
+	if _, ok := conditionValue.(map[string]any); ok {
+		return c.checkElementOperator(operator, key, fact, conditionValue, instance)
+	}
+	if c.legacyListOperators {
+		return checkLegacyListOperator(operator, key, fact, conditionValue)
+	}
+
+	elements, ok := listElements(fact)
+	if !ok {
+		return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
+	}
+	values, ok := listElements(conditionValue)
+	if !ok {
+		values = []any{conditionValue}
+	}
+
+	for _, element := range elements {
+		found := c.containsValue(values, element)
+		switch operator {
+		case SOME:
+			if found {
+				return true, nil
+			}
+		case EVERY:
+			if !found {
+				return false, nil
+			}
+		case NOONE:
+			if found {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unhandled operator %s", operator)
+		}
+	}
+	return operator != SOME, nil
+}
+
+// checkSetOperator handles the set operators, which treat the fact and the operand as sets of values:
+//
+//   - $all and $superset: every operand value is in the fact
+//   - $subset: every element of the fact is an operand value
+//   - $disjoint: no element of the fact is an operand value
+//   - $intersects: at least one operand value, or {"values": [...], "min": n} distinct values, is in the fact
+//   - $setEquals: both $subset and $superset
+func (c *Conditions) checkSetOperator(operator CommonOperatorsEnum, key string, fact any, conditionValue any) (bool, error) {
+	elements, ok := listElements(fact)
+	if !ok {
+		return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
+	}
+
+	minOverlap := int64(1)
+	if spec, ok := conditionValue.(map[string]any); ok && operator == INTERSECTS {
+		conditionValue = c.prepareText(spec["values"])
+		if raw, exists := spec["min"]; exists {
+			n, ok := toBigInt(raw)
+			if !ok || !n.IsInt64() || n.Cmp(big.NewInt(1)) < 0 {
+				return false, fmt.Errorf("expected positive integer for $intersects min, got %v", raw)
+			}
+			minOverlap = n.Int64()
+		}
+	}
+	values, ok := listElements(conditionValue)
+	if !ok {
+		return false, fmt.Errorf("expected list of values for %s operator, got %T", operator, conditionValue)
+	}
+
+	switch operator {
+	case ALL, SUPERSET:
+		return c.isSubset(values, elements), nil
+	case SUBSET:
+		return c.isSubset(elements, values), nil
+	case DISJOINT:
+		for _, element := range elements {
+			if c.containsValue(values, element) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case INTERSECTS:
+		var overlap int64
+		for i, value := range values {
+			if !c.containsValue(values[:i], value) && c.containsValue(elements, value) {
+				overlap++
+			}
+		}
+		return overlap >= minOverlap, nil
+	case SETEQUALS:
+		return c.isSubset(elements, values) && c.isSubset(values, elements), nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
+
+// isSubset reports whether every value of a is in b.
+func (c *Conditions) isSubset(a, b []any) bool {
+	for _, value := range a {
+		if !c.containsValue(b, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkLegacyListOperator implements $some, $every and $noone with value operands as they behaved
+// before the set operators existed. It is used with WithLegacyListOperators.
+func checkLegacyListOperator(operator CommonOperatorsEnum, key string, fact any, conditionValue any) (bool, error) {
+	factVal := reflect.ValueOf(fact)
+	if factVal.Kind() != reflect.Slice {
+		return false, fmt.Errorf("expected a slice for instance value under key %s, got %T", key, fact)
+	}
+
+	conditionVal := reflect.ValueOf(conditionValue)
+
+	if operator == SOME {
+		// Handle conditionValue as a slice
+		if conditionVal.Kind() == reflect.Slice {
+			// Iterate over each item in the conditionValue slice.
+			for i := 0; i < conditionVal.Len(); i++ {
+				conditionItem := conditionVal.Index(i).Interface()
+
+				// Check if conditionItem is in factVal slice.
+				for j := 0; j < factVal.Len(); j++ {
+					factItem := factVal.Index(j).Interface()
+					if reflect.DeepEqual(factItem, conditionItem) {
+						return true, nil // Found matching element.
+					}
+				}
+			}
+			return false, nil // No matching elements found.
+		}
+		// Handle single condition value
+		singleCondition := conditionVal.Interface()
+		for j := 0; j < factVal.Len(); j++ {
+			factItem := factVal.Index(j).Interface()
+			if reflect.DeepEqual(factItem, singleCondition) {
+				return true, nil // Found matching element.
+			}
+		}
+		return false, nil // No matching element found.
+	}
+
+	// Handle conditionValue as a slice
+	if conditionVal.Kind() == reflect.Slice {
+		var result bool
+		// Iterate over each item in the conditionValue slice.
+		for i := 0; i < conditionVal.Len(); i++ {
+			result = false
+			conditionItem := conditionVal.Index(i).Interface()
+
+			// Check if conditionItem is in factVal slice.
+			for j := 0; j < factVal.Len(); j++ {
+				factItem := factVal.Index(j).Interface()
+				eq := reflect.DeepEqual(factItem, conditionItem)
+
+				if operator == EVERY && eq || operator == NOONE && !eq {
+					result = true
+				}
+				if operator == NOONE && eq {
+					return false, nil
+				}
+			}
+
+			if !result {
+				return false, nil
+			}
+		}
+
+		return result, nil
+	}
+
+	// Handle single condition value
+	conditionItem := conditionVal.Interface()
+	for j := 0; j < factVal.Len(); j++ {
+		factItem := factVal.Index(j).Interface()
+		eq := reflect.DeepEqual(factItem, conditionItem)
+
+		if operator == NOONE && eq {
+			return false, nil
+		}
+		if operator == EVERY && eq {
+			return true, nil
+		}
+	}
+
+	return operator == NOONE, nil
+}
@@ -0,0 +1,122 @@
+package conditions
+
+import (
+	"testing"
+)
+
+func TestSetOperators(t *testing.T) {
+	cond := NewConditions()
+	legacy := NewConditions(WithLegacyListOperators())
+	instance := map[string]any{
+		"tags":  []string{"go", "rust", "go"},
+		"roles": []any{"admin", "editor"},
+		"ids":   []int{1, 2, 3},
+		"empty": []string{},
+	}
+
+	tests := []struct {
+		name      string
+		cond      *Conditions
+		condition map[string]any
+		want      bool
+	}{
+		{
+			name:      "Test $all operator true",
+			cond:      cond,
+			condition: map[string]any{"{{tags}}": map[string]any{"$all": []string{"go", "rust"}}},
+			want:      true,
+		},
+		{
+			name:      "Test $superset operator false",
+			cond:      cond,
+			condition: map[string]any{"{{tags}}": map[string]any{"$superset": []string{"go", "zig"}}},
+			want:      false,
+		},
+		{
+			name:      "Test $subset operator true",
+			cond:      cond,
+			condition: map[string]any{"{{roles}}": map[string]any{"$subset": []string{"admin", "editor", "viewer"}}},
+			want:      true,
+		},
+		{
+			name:      "Test $subset operator with empty fact",
+			cond:      cond,
+			condition: map[string]any{"{{empty}}": map[string]any{"$subset": []string{"admin"}}},
+			want:      true,
+		},
+		{
+			name:      "Test $disjoint operator true",
+			cond:      cond,
+			condition: map[string]any{"{{ids}}": map[string]any{"$disjoint": []int{4, 5}}},
+			want:      true,
+		},
+		{
+			name:      "Test $intersects operator true",
+			cond:      cond,
+			condition: map[string]any{"{{ids}}": map[string]any{"$intersects": []int{3, 4}}},
+			want:      true,
+		},
+		{
+			name:      "Test $intersects operator with minimum overlap",
+			cond:      cond,
+			condition: map[string]any{"{{ids}}": map[string]any{"$intersects": map[string]any{"values": []int{2, 3, 3, 9}, "min": 3}}},
+			want:      false,
+		},
+		{
+			name:      "Test $intersects operator with invalid minimum",
+			cond:      cond,
+			condition: map[string]any{"{{ids}}": map[string]any{"$intersects": map[string]any{"values": []int{2}, "min": 0}}},
+			want:      false,
+		},
+		{
+			name:      "Test $setEquals operator ignores order and duplicates",
+			cond:      cond,
+			condition: map[string]any{"{{tags}}": map[string]any{"$setEquals": []string{"rust", "go"}}},
+			want:      true,
+		},
+		{
+			name:      "Test $every operator with list operand",
+			cond:      cond,
+			condition: map[string]any{"{{ids}}": map[string]any{"$every": []int{1, 2, 3, 4}}},
+			want:      true,
+		},
+		{
+			name:      "Test $every operator with single value",
+			cond:      cond,
+			condition: map[string]any{"{{tags}}": map[string]any{"$every": "go"}},
+			want:      false,
+		},
+		{
+			name:      "Test $noone operator with empty fact",
+			cond:      cond,
+			condition: map[string]any{"{{empty}}": map[string]any{"$noone": []string{"go"}}},
+			want:      true,
+		},
+		{
+			name:      "Test legacy $every operator with list operand",
+			cond:      legacy,
+			condition: map[string]any{"{{ids}}": map[string]any{"$every": []int{1, 2, 3, 4}}},
+			want:      false,
+		},
+		{
+			name:      "Test legacy $every operator with single value",
+			cond:      legacy,
+			condition: map[string]any{"{{tags}}": map[string]any{"$every": "go"}},
+			want:      true,
+		},
+		{
+			name:      "Test legacy $noone operator with empty fact",
+			cond:      legacy,
+			condition: map[string]any{"{{empty}}": map[string]any{"$noone": []string{"go"}}},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Check(instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
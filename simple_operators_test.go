@@ -71,6 +71,26 @@ func TestCheckSimpleOperators(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Test BLANK operator true for whitespace string",
+			condition: map[string]any{
+				"$blank": "spaces",
+			},
+			instance: map[string]any{
+				"spaces": " \t\n ",
+			},
+			want: true,
+		},
+		{
+			name: "Test BLANK operator false for non-empty string",
+			condition: map[string]any{
+				"$blank": "word",
+			},
+			instance: map[string]any{
+				"word": " a ",
+			},
+			want: false,
+		},
 		{
 			name: "Test TRULY operator true",
 			condition: map[string]any{
@@ -0,0 +1,183 @@
+package conditions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stringOperands asserts that both the fact and the operand of a string operator are strings.
+func stringOperands(operator CommonOperatorsEnum, fact any, conditionValue any) (string, string, error) {
+	operand, ok := conditionValue.(string)
+	if !ok {
+		return "", "", fmt.Errorf("expected string for %s operator, got %T", operator, conditionValue)
+	}
+	str, ok := fact.(string)
+	if !ok {
+		return "", "", fmt.Errorf("expected string for instance value, got %T", fact)
+	}
+	return str, operand, nil
+}
+
+// foldString maps every rune to the canonical member of its Unicode simple case folding orbit,
+// so that two strings are equal after folding exactly when strings.EqualFold reports them equal.
+func foldString(s string) string {
+	return strings.Map(foldRune, s)
+}
+
+func foldRune(r rune) rune {
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < folded {
+			folded = f
+		}
+	}
+	return folded
+}
+
+// checkStringOperator evaluates the string matching operators against a fact.
+func (c *Conditions) checkStringOperator(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	if operator == LEN {
+		return c.checkLength(fact, conditionValue)
+	}
+
+	str, operand, err := stringOperands(operator, fact, conditionValue)
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case CONTAINS:
+		return strings.Contains(str, operand), nil
+	case IEQ:
+		return strings.EqualFold(str, operand), nil
+	case ISW:
+		return strings.HasPrefix(foldString(str), foldString(operand)), nil
+	case IEW:
+		return strings.HasSuffix(foldString(str), foldString(operand)), nil
+	case ICONTAINS:
+		return strings.Contains(foldString(str), foldString(operand)), nil
+	case LIKE, ILIKE, GLOB:
+		if err := c.checkPatternLength(operator, operand); err != nil {
+			return false, err
+		}
+		var expr string
+		if operator == GLOB {
+			expr, err = globToRegex(operand)
+		} else {
+			expr, err = likeToRegex(operand)
+		}
+		if err != nil {
+			return false, err
+		}
+		if operator == ILIKE {
+			expr = "(?i)" + expr
+		}
+		re, err := c.compileCachedRegex(expr)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(str), nil
+	default:
+		return false, fmt.Errorf("unhandled operator %s", operator)
+	}
+}
+
+// checkLength compares the length of a string fact in runes. The operand is either a number
+// for an exact match or a map of numeric operators, e.g. {"$len": {"$gte": 3, "$lte": 10}}.
+func (c *Conditions) checkLength(fact any, conditionValue any) (bool, error) {
+	str, ok := fact.(string)
+	if !ok {
+		return false, fmt.Errorf("expected string for instance value, got %T", fact)
+	}
+	return c.checkDerivedNumber(LEN, utf8.RuneCountInString(str), conditionValue)
+}
+
+// likeToRegex converts an SQL LIKE pattern into an anchored regular expression.
+// % matches any sequence, _ matches a single character and \ escapes the next character.
+func likeToRegex(pattern string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("(?s)^")
+
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		return "", fmt.Errorf("trailing escape in $like pattern %q", pattern)
+	}
+
+	sb.WriteString("$")
+	return sb.String(), nil
+}
+
+// globToRegex converts a shell glob into an anchored regular expression.
+// * matches any sequence, ? matches a single character and [...] / [!...] match character classes.
+func globToRegex(pattern string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("(?s)^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		case '\\':
+			if i+1 >= len(runes) {
+				return "", fmt.Errorf("trailing escape in $glob pattern %q", pattern)
+			}
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case '[':
+			end := i + 1
+			if end < len(runes) && (runes[end] == '!' || runes[end] == '^') {
+				end++
+			}
+			if end < len(runes) && runes[end] == ']' {
+				end++
+			}
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end >= len(runes) {
+				return "", fmt.Errorf("unterminated character class in $glob pattern %q", pattern)
+			}
+
+			sb.WriteByte('[')
+			class := runes[i+1 : end]
+			if len(class) > 0 && (class[0] == '!' || class[0] == '^') {
+				sb.WriteByte('^')
+				class = class[1:]
+			}
+			for _, cr := range class {
+				if cr == '\\' || cr == '[' || cr == ']' {
+					sb.WriteByte('\\')
+				}
+				sb.WriteRune(cr)
+			}
+			sb.WriteByte(']')
+			i = end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String(), nil
+}
@@ -0,0 +1,121 @@
+package conditions
+
+import (
+	"testing"
+)
+
+func TestStringOperators(t *testing.T) {
+	cond := NewConditions()
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $contains operator true",
+			condition: map[string]any{"{{title}}": map[string]any{"$contains": "lang"}},
+			instance:  map[string]any{"title": "golang rules"},
+			want:      true,
+		},
+		{
+			name:      "Test $contains operator is case-sensitive",
+			condition: map[string]any{"{{title}}": map[string]any{"$contains": "Lang"}},
+			instance:  map[string]any{"title": "golang rules"},
+			want:      false,
+		},
+		{
+			name:      "Test $ieq operator true",
+			condition: map[string]any{"{{city}}": map[string]any{"$ieq": "ZÜRICH"}},
+			instance:  map[string]any{"city": "zürich"},
+			want:      true,
+		},
+		{
+			name:      "Test $isw operator true",
+			condition: map[string]any{"{{name}}": map[string]any{"$isw": "ΣΟ"}},
+			instance:  map[string]any{"name": "σοφία"},
+			want:      true,
+		},
+		{
+			name:      "Test $iew operator true",
+			condition: map[string]any{"{{file}}": map[string]any{"$iew": ".TXT"}},
+			instance:  map[string]any{"file": "notes.txt"},
+			want:      true,
+		},
+		{
+			name:      "Test $icontains operator with Kelvin sign",
+			condition: map[string]any{"{{unit}}": map[string]any{"$icontains": "k"}},
+			instance:  map[string]any{"unit": "300 K"},
+			want:      true,
+		},
+		{
+			name:      "Test $like operator true",
+			condition: map[string]any{"{{sku}}": map[string]any{"$like": "AB_-%"}},
+			instance:  map[string]any{"sku": "ABC-12345"},
+			want:      true,
+		},
+		{
+			name:      "Test $like operator with escaped wildcard",
+			condition: map[string]any{"{{rate}}": map[string]any{"$like": "100\\%"}},
+			instance:  map[string]any{"rate": "1000"},
+			want:      false,
+		},
+		{
+			name:      "Test $ilike operator true",
+			condition: map[string]any{"{{email}}": map[string]any{"$ilike": "%@EXAMPLE.com"}},
+			instance:  map[string]any{"email": "john@example.com"},
+			want:      true,
+		},
+		{
+			name:      "Test $glob operator true",
+			condition: map[string]any{"{{path}}": map[string]any{"$glob": "logs/*.[lt]og"}},
+			instance:  map[string]any{"path": "logs/app.log"},
+			want:      true,
+		},
+		{
+			name:      "Test $glob operator with negated class",
+			condition: map[string]any{"{{path}}": map[string]any{"$glob": "v[!0]?"}},
+			instance:  map[string]any{"path": "v01"},
+			want:      false,
+		},
+		{
+			name:      "Test $len operator with exact length",
+			condition: map[string]any{"{{name}}": map[string]any{"$len": 5}},
+			instance:  map[string]any{"name": "Zoë!!"},
+			want:      true,
+		},
+		{
+			name:      "Test $len operator with nested operators",
+			condition: map[string]any{"{{name}}": map[string]any{"$len": map[string]any{"$gte": 3, "$lte": 4}}},
+			instance:  map[string]any{"name": "Anna"},
+			want:      true,
+		},
+		{
+			name:      "Test $len operator with nested operators false",
+			condition: map[string]any{"{{name}}": map[string]any{"$len": map[string]any{"$gt": 4}}},
+			instance:  map[string]any{"name": "Anna"},
+			want:      false,
+		},
+		{
+			name:      "Test $len operator with nested operators above the upper bound",
+			condition: map[string]any{"{{name}}": map[string]any{"$len": map[string]any{"$gte": 3, "$lte": 10}}},
+			instance:  map[string]any{"name": "Maximiliana"},
+			want:      false,
+		},
+		{
+			name:      "Test $icontains operator with non-string fact",
+			condition: map[string]any{"{{count}}": map[string]any{"$icontains": "1"}},
+			instance:  map[string]any{"count": 10},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package conditions
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// textOptions controls how string facts and operands are prepared before comparison.
+type textOptions struct {
+	normalize bool
+	form      norm.Form
+	fold      bool
+	collator  *lockedCollator
+}
+
+// lockedCollator guards a collator, which keeps internal buffers and is not safe for concurrent use.
+type lockedCollator struct {
+	mu       sync.Mutex
+	collator *collate.Collator
+}
+
+func (lc *lockedCollator) compare(a, b string) int {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.collator.CompareString(a, b)
+}
+
+// WithNormalization applies the given Unicode normalization form (usually norm.NFC or norm.NFKC)
+// to string facts and operands before they are compared.
+func WithNormalization(form norm.Form) Option {
+	return func(c *Conditions) {
+		c.text.normalize = true
+		c.text.form = form
+	}
+}
+
+// WithCaseFolding applies Unicode case folding to string facts and operands before they are compared.
+func WithCaseFolding() Option {
+	return func(c *Conditions) {
+		c.text.fold = true
+	}
+}
+
+// WithCollation orders strings in $lt, $gt, $lte, $gte and $between using the collation rules of the given locale.
+func WithCollation(locale language.Tag) Option {
+	return func(c *Conditions) {
+		c.text.collator = &lockedCollator{collator: collate.New(locale)}
+	}
+}
+
+// textOperators compare strings as text, so their facts and operands are normalized and case folded.
+// Other operators, like $re or $semverSatisfies, keep their operands as written. Ordering operators
+// prepare their operands in compare, once they are known to be plain text.
+var textOperators = map[CommonOperatorsEnum]bool{
+	EQ: true, NE: true,
+	IN: true, NI: true, SW: true, EW: true, INCL: true, EXCL: true, HAS: true,
+	SOME: true, EVERY: true, NOONE: true,
+	ALL: true, SUPERSET: true, SUBSET: true, DISJOINT: true, INTERSECTS: true, SETEQUALS: true,
+	UNIQUE: true, DISTINCTCOUNT: true,
+	CONTAINS: true, IEQ: true, ISW: true, IEW: true, ICONTAINS: true, LIKE: true, ILIKE: true, GLOB: true,
+}
+
+// prepareText normalizes and case folds a string, or the strings inside a slice, according to the options.
+// Other values are returned unchanged.
+func (c *Conditions) prepareText(value any) any {
+	if !c.text.normalize && !c.text.fold {
+		return value
+	}
+
+	switch v := value.(type) {
+	case string:
+		return c.prepareString(v)
+	case []string:
+		prepared := make([]string, len(v))
+		for i, s := range v {
+			prepared[i] = c.prepareString(s)
+		}
+		return prepared
+	case []any:
+		prepared := make([]any, len(v))
+		for i, item := range v {
+			prepared[i] = c.prepareText(item)
+		}
+		return prepared
+	}
+
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.String {
+		return c.prepareString(rv.String())
+	}
+	return value
+}
+
+func (c *Conditions) prepareString(s string) string {
+	if c.text.fold {
+		// Folding may produce denormalized output, so it runs before normalization.
+		s = cases.Fold().String(s)
+	}
+	if c.text.normalize {
+		s = c.text.form.String(s)
+	}
+	return s
+}
+
+// compareText orders two strings as text, normalized, case folded and collated according to the options.
+// It reports false when no text option is set, or when either string is a time, a relative date, a duration
+// or a byte size, which are ordered by value and must keep their spelling.
+func (c *Conditions) compareText(v1, v2 any) (int, bool) {
+	if !c.text.normalize && !c.text.fold && c.text.collator == nil {
+		return 0, false
+	}
+	s1, ok1 := v1.(string)
+	s2, ok2 := v2.(string)
+	if !ok1 || !ok2 || !c.isPlainText(s1) || !c.isPlainText(s2) {
+		return 0, false
+	}
+
+	s1, s2 = c.prepareString(s1), c.prepareString(s2)
+	if c.text.collator != nil {
+		return c.text.collator.compare(s1, s2), true
+	}
+	return strings.Compare(s1, s2), true
+}
+
+// isPlainText reports whether s is ordinary text rather than a value with a textual representation.
+func (c *Conditions) isPlainText(s string) bool {
+	if _, err := toTimeFact(s); err == nil {
+		return false
+	}
+	if _, ok := c.resolveRelativeTime(s); ok {
+		return false
+	}
+	if _, ok := toDuration(s); ok {
+		return false
+	}
+	_, ok := parseByteSize(s)
+	return !ok
+}
@@ -0,0 +1,130 @@
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestTextNormalizationAndCollation(t *testing.T) {
+	const precomposed = "café"
+	const decomposed = "café"
+	clock := WithClock(func() time.Time { return time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC) })
+
+	tests := []struct {
+		name      string
+		cond      *Conditions
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $eq without normalization compares bytes",
+			cond:      NewConditions(),
+			condition: map[string]any{"{{name}}": map[string]any{"$eq": precomposed}},
+			instance:  map[string]any{"name": decomposed},
+			want:      false,
+		},
+		{
+			name:      "Test $eq with NFC normalization",
+			cond:      NewConditions(WithNormalization(norm.NFC)),
+			condition: map[string]any{"{{name}}": map[string]any{"$eq": precomposed}},
+			instance:  map[string]any{"name": decomposed},
+			want:      true,
+		},
+		{
+			name:      "Test $sw with NFKC normalization",
+			cond:      NewConditions(WithNormalization(norm.NFKC)),
+			condition: map[string]any{"{{name}}": map[string]any{"$sw": "ffi"}},
+			instance:  map[string]any{"name": "ﬃce"},
+			want:      true,
+		},
+		{
+			name:      "Test $in with case folding",
+			cond:      NewConditions(WithCaseFolding()),
+			condition: map[string]any{"{{street}}": map[string]any{"$in": []string{"hauptstrasse"}}},
+			instance:  map[string]any{"street": "HAUPTSTRASSE"},
+			want:      true,
+		},
+		{
+			name:      "Test bare equality with case folding and normalization",
+			cond:      NewConditions(WithCaseFolding(), WithNormalization(norm.NFC)),
+			condition: map[string]any{"{{name}}": "{{alias}}"},
+			instance:  map[string]any{"name": "CAFÉ", "alias": decomposed},
+			want:      true,
+		},
+		{
+			name:      "Test $re operand is not folded",
+			cond:      NewConditions(WithCaseFolding()),
+			condition: map[string]any{"{{code}}": map[string]any{"$re": "^\\S+$"}},
+			instance:  map[string]any{"code": "AB-1"},
+			want:      true,
+		},
+		{
+			name:      "Test $lt with German collation",
+			cond:      NewConditions(WithCollation(language.German)),
+			condition: map[string]any{"{{name}}": map[string]any{"$lt": "Zander"}},
+			instance:  map[string]any{"name": "Äpfel"},
+			want:      true,
+		},
+		{
+			name:      "Test $lt with Swedish collation",
+			cond:      NewConditions(WithCollation(language.Swedish)),
+			condition: map[string]any{"{{name}}": map[string]any{"$lt": "Zander"}},
+			instance:  map[string]any{"name": "Äpfel"},
+			want:      false,
+		},
+		{
+			name:      "Test $between with Turkish collation",
+			cond:      NewConditions(WithCollation(language.Turkish)),
+			condition: map[string]any{"{{name}}": map[string]any{"$between": []string{"Cem", "Dora"}}},
+			instance:  map[string]any{"name": "Çağla"},
+			want:      true,
+		},
+		{
+			name:      "Test $gt with case folding orders plain text",
+			cond:      NewConditions(WithCaseFolding()),
+			condition: map[string]any{"{{name}}": map[string]any{"$gt": "apple"}},
+			instance:  map[string]any{"name": "BANANA"},
+			want:      true,
+		},
+		{
+			name:      "Test $gte with case folding keeps relative dates",
+			cond:      NewConditions(WithCaseFolding(), clock),
+			condition: map[string]any{"{{created}}": map[string]any{"$gte": "startOfMonth"}},
+			instance:  map[string]any{"created": time.Date(2026, time.October, 5, 0, 0, 0, 0, time.UTC)},
+			want:      true,
+		},
+		{
+			name:      "Test $lt with case folding keeps RFC 3339 operands",
+			cond:      NewConditions(WithCaseFolding()),
+			condition: map[string]any{"{{created}}": map[string]any{"$lt": "2026-11-01T00:00:00Z"}},
+			instance:  map[string]any{"created": time.Date(2026, time.October, 5, 0, 0, 0, 0, time.UTC)},
+			want:      true,
+		},
+		{
+			name:      "Test $between with case folding keeps RFC 3339 facts",
+			cond:      NewConditions(WithCaseFolding()),
+			condition: map[string]any{"{{created}}": map[string]any{"$between": []any{"2026-10-01T00:00:00Z", "2026-10-31T00:00:00Z"}}},
+			instance:  map[string]any{"created": "2026-10-05T00:00:00Z"},
+			want:      true,
+		},
+		{
+			name:      "Test $lt with collation compares durations by value",
+			cond:      NewConditions(WithCollation(language.German)),
+			condition: map[string]any{"{{timeout}}": map[string]any{"$lt": "15m"}},
+			instance:  map[string]any{"timeout": 9 * time.Minute},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package conditions
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Truthiness selects how $truly, $falsy, $blank and bare path conditions decide whether a value is true.
+type Truthiness int
+
+const (
+	// TruthinessStrict only treats the bool true as truthy and false as falsy, after coercion.
+	// Other values, including missing fields, are neither. This is the default.
+	TruthinessStrict Truthiness = iota
+	// TruthinessJavaScript treats false, 0, NaN, "", nil and missing fields as falsy and everything else,
+	// including empty lists and objects, as truthy.
+	TruthinessJavaScript
+	// TruthinessPython treats false, 0, "", empty lists and maps, nil and missing fields as falsy
+	// and everything else as truthy.
+	TruthinessPython
+)
+
+// WithTruthiness sets the truthiness model.
+func WithTruthiness(model Truthiness) Option {
+	return func(c *Conditions) {
+		c.truthiness = model
+	}
+}
+
+// isTruthy reports whether $truly matches the fact.
+func (c *Conditions) isTruthy(fact any) bool {
+	switch c.truthiness {
+	case TruthinessJavaScript:
+		return javaScriptTruthy(coerceNumber(fact))
+	case TruthinessPython:
+		return pythonTruthy(coerceNumber(fact))
+	default:
+		return c.coerceTruth(fact) == true
+	}
+}
+
+// isFalsy reports whether $falsy matches the fact. In the strict model a value can be neither truthy nor falsy.
+func (c *Conditions) isFalsy(fact any) bool {
+	if c.truthiness == TruthinessStrict {
+		return c.coerceTruth(fact) == false
+	}
+	return !c.isTruthy(fact)
+}
+
+// isBlank reports whether $blank matches the fact: nil, whitespace-only strings, empty collections,
+// zero structs, SQL NULLs and values that are falsy in the truthiness model. The strict model uses Python's zero values here,
+// since a blank check is about missing content rather than the bool false.
+func (c *Conditions) isBlank(fact any) bool {
+	fact = indirect(fact)
+	if isNil(fact) || isEmpty(fact) {
+		return true
+	}
+	if str, ok := fact.(string); ok && strings.TrimSpace(str) == "" {
+		return true
+	}
+	if c.truthiness == TruthinessJavaScript {
+		return !javaScriptTruthy(coerceNumber(fact))
+	}
+	return !pythonTruthy(coerceNumber(fact))
+}
+
+// pythonTruthy implements Python's truth value testing for Go values.
+func pythonTruthy(value any) bool {
+	if isNil(value) || isEmpty(value) {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0
+	default:
+		return true
+	}
+}
+
+// javaScriptTruthy implements JavaScript's ToBoolean for Go values.
+func javaScriptTruthy(value any) bool {
+	if isNil(value) {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.Len() > 0
+	}
+	if f, ok := toFloat64(value); ok {
+		return f != 0 && !math.IsNaN(f)
+	}
+	return true
+}
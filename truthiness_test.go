@@ -0,0 +1,127 @@
+package conditions
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTruthinessModels(t *testing.T) {
+	strict := NewConditions()
+	javaScript := NewConditions(WithTruthiness(TruthinessJavaScript))
+	python := NewConditions(WithTruthiness(TruthinessPython))
+
+	tests := []struct {
+		name      string
+		cond      *Conditions
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $truly with number in strict model",
+			cond:      strict,
+			condition: map[string]any{"$truly": "{{count}}"},
+			instance:  map[string]any{"count": 3},
+			want:      false,
+		},
+		{
+			name:      "Test $falsy with missing field in strict model",
+			cond:      strict,
+			condition: map[string]any{"$falsy": "{{flag}}"},
+			instance:  map[string]any{},
+			want:      false,
+		},
+		{
+			name:      "Test $truly with empty list in JavaScript model",
+			cond:      javaScript,
+			condition: map[string]any{"$truly": "{{tags}}"},
+			instance:  map[string]any{"tags": []string{}},
+			want:      true,
+		},
+		{
+			name:      "Test $falsy with NaN in JavaScript model",
+			cond:      javaScript,
+			condition: map[string]any{"$falsy": "{{score}}"},
+			instance:  map[string]any{"score": math.NaN()},
+			want:      true,
+		},
+		{
+			name:      "Test $blank with NaN in JavaScript model",
+			cond:      javaScript,
+			condition: map[string]any{"$blank": "{{score}}"},
+			instance:  map[string]any{"score": math.NaN()},
+			want:      true,
+		},
+		{
+			name:      "Test $falsy with empty list in Python model",
+			cond:      python,
+			condition: map[string]any{"$falsy": "{{tags}}"},
+			instance:  map[string]any{"tags": []string{}},
+			want:      true,
+		},
+		{
+			name:      "Test $truly with missing field in Python model",
+			cond:      python,
+			condition: map[string]any{"$truly": "{{flag}}"},
+			instance:  map[string]any{},
+			want:      false,
+		},
+		{
+			name:      "Test $blank with zero in strict model",
+			cond:      strict,
+			condition: map[string]any{"$blank": "{{count}}"},
+			instance:  map[string]any{"count": 0},
+			want:      true,
+		},
+		{
+			name:      "Test $empty with empty map in JavaScript model",
+			cond:      javaScript,
+			condition: map[string]any{"$empty": "{{meta}}"},
+			instance:  map[string]any{"meta": map[string]any{}},
+			want:      true,
+		},
+		{
+			name:      "Test $empty with empty list in JavaScript model",
+			cond:      javaScript,
+			condition: map[string]any{"$empty": "{{tags}}"},
+			instance:  map[string]any{"tags": []string{}},
+			want:      true,
+		},
+		{
+			name:      "Test $empty with zero struct in Python model",
+			cond:      python,
+			condition: map[string]any{"$empty": "{{point}}"},
+			instance:  map[string]any{"point": struct{ X, Y int }{}},
+			want:      true,
+		},
+		{
+			name:      "Test bare path in $and in Python model",
+			cond:      python,
+			condition: map[string]any{"$and": []any{"{{user.name}}", "{{user.roles}}"}},
+			instance:  map[string]any{"user": map[string]any{"name": "Ann", "roles": []string{"admin"}}},
+			want:      true,
+		},
+		{
+			name:      "Test bare path in $or in JavaScript model",
+			cond:      javaScript,
+			condition: map[string]any{"$or": []any{"{{user.email}}", "{{user.phone}}"}},
+			instance:  map[string]any{"user": map[string]any{"email": "", "phone": 0}},
+			want:      false,
+		},
+		{
+			name:      "Test bare path with bool in strict model",
+			cond:      strict,
+			condition: map[string]any{"$and": []any{"{{user.active}}"}},
+			instance:  map[string]any{"user": map[string]any{"active": true}},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
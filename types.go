@@ -0,0 +1,130 @@
+package conditions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Type names used by the $type operator.
+const (
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeBool    = "bool"
+	TypeArray   = "array"
+	TypeObject  = "object"
+	TypeNull    = "null"
+	TypeTime    = "time"
+)
+
+var typeNames = map[string]bool{
+	TypeString: true, TypeNumber: true, TypeInteger: true, TypeBool: true,
+	TypeArray: true, TypeObject: true, TypeNull: true, TypeTime: true,
+}
+
+// typeOf classifies a fact the same way for Go values and values decoded from JSON:
+// float64(42) from JSON and int 42 are both integers, nil pointers are null, structs and maps are objects.
+// Strings are never classified as numbers, so "42" is a string.
+func typeOf(value any) string {
+	if isNil(value) {
+		return TypeNull
+	}
+
+	if isArbitraryPrecision(value) {
+		if n, ok := toNumber(value); ok && n.rat != nil && n.rat.IsInt() {
+			return TypeInteger
+		}
+		return TypeNumber
+	}
+
+	val := reflect.ValueOf(value)
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return TypeNull
+		}
+		val = val.Elem()
+	}
+	if val.Type() == reflect.TypeOf(time.Time{}) {
+		return TypeTime
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		return TypeString
+	case reflect.Bool:
+		return TypeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return TypeInteger
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		if f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return TypeInteger
+		}
+		return TypeNumber
+	case reflect.Slice, reflect.Array:
+		return TypeArray
+	case reflect.Map, reflect.Struct:
+		return TypeObject
+	default:
+		return val.Kind().String()
+	}
+}
+
+// isOfType reports whether the fact has the named type. Integers are numbers as well.
+func isOfType(fact any, name string) bool {
+	actual := typeOf(fact)
+	return actual == name || name == TypeNumber && actual == TypeInteger
+}
+
+// checkType evaluates $type with a type name or a list of names, and the $is... shorthands with a bool.
+func (c *Conditions) checkType(operator CommonOperatorsEnum, fact any, conditionValue any) (bool, error) {
+	if name, ok := typeShorthands[operator]; ok {
+		expected, ok := conditionValue.(bool)
+		if !ok {
+			return false, fmt.Errorf("expected bool for %s operator, got %T", operator, conditionValue)
+		}
+		return isOfType(fact, name) == expected, nil
+	}
+
+	var names []string
+	switch v := conditionValue.(type) {
+	case string:
+		names = []string{v}
+	default:
+		val := reflect.ValueOf(conditionValue)
+		if val.Kind() != reflect.Slice {
+			return false, fmt.Errorf("expected type name or list of type names for $type operator, got %T", conditionValue)
+		}
+		for i := 0; i < val.Len(); i++ {
+			name, ok := val.Index(i).Interface().(string)
+			if !ok {
+				return false, fmt.Errorf("expected type name for $type operator, got %T", val.Index(i).Interface())
+			}
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		if !typeNames[name] {
+			return false, fmt.Errorf("unknown type %q for $type operator", name)
+		}
+		if isOfType(fact, name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var typeShorthands = map[CommonOperatorsEnum]string{
+	ISSTRING:  TypeString,
+	ISNUMBER:  TypeNumber,
+	ISINTEGER: TypeInteger,
+	ISBOOL:    TypeBool,
+	ISARRAY:   TypeArray,
+	ISOBJECT:  TypeObject,
+	ISNULL:    TypeNull,
+	ISTIME:    TypeTime,
+}
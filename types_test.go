@@ -0,0 +1,138 @@
+package conditions
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTypeOf(t *testing.T) {
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(`{"count": 42, "ratio": 0.5, "label": "42", "list": [1], "obj": {}, "none": null, "flag": true}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	var nilPointer *int
+	moment := time.Now()
+
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{decoded["count"], TypeInteger},
+		{decoded["ratio"], TypeNumber},
+		{decoded["label"], TypeString},
+		{decoded["list"], TypeArray},
+		{decoded["obj"], TypeObject},
+		{decoded["none"], TypeNull},
+		{decoded["flag"], TypeBool},
+		{42, TypeInteger},
+		{uint8(7), TypeInteger},
+		{float32(1.5), TypeNumber},
+		{math.Inf(1), TypeNumber},
+		{json.Number("12"), TypeInteger},
+		{json.Number("1.25"), TypeNumber},
+		{[]string{"a"}, TypeArray},
+		{[2]int{1, 2}, TypeArray},
+		{map[string]int{}, TypeObject},
+		{struct{ Name string }{"x"}, TypeObject},
+		{nilPointer, TypeNull},
+		{nil, TypeNull},
+		{moment, TypeTime},
+		{&moment, TypeTime},
+	}
+
+	for _, tt := range tests {
+		if got := typeOf(tt.value); got != tt.want {
+			t.Errorf("typeOf(%#v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTypeOperators(t *testing.T) {
+	cond := NewConditions()
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $type operator rejects numeric string",
+			condition: map[string]any{"{{age}}": map[string]any{"$type": "number"}},
+			instance:  map[string]any{"age": "42"},
+			want:      false,
+		},
+		{
+			name:      "Test $type operator guards comparison",
+			condition: map[string]any{"{{age}}": map[string]any{"$type": "integer", "$gt": 18}},
+			instance:  map[string]any{"age": 42},
+			want:      true,
+		},
+		{
+			name:      "Test $type operator guards comparison with string fact",
+			condition: map[string]any{"{{age}}": map[string]any{"$type": "integer", "$gte": 18}},
+			instance:  map[string]any{"age": "42"},
+			want:      false,
+		},
+		{
+			name:      "Test $type operator with list of types",
+			condition: map[string]any{"{{id}}": map[string]any{"$type": []string{"string", "integer"}}},
+			instance:  map[string]any{"id": "abc"},
+			want:      true,
+		},
+		{
+			name:      "Test $type operator with unknown type",
+			condition: map[string]any{"{{id}}": map[string]any{"$type": "uuid"}},
+			instance:  map[string]any{"id": "abc"},
+			want:      false,
+		},
+		{
+			name:      "Test $type operator null for missing field",
+			condition: map[string]any{"{{missing}}": map[string]any{"$type": "null"}},
+			instance:  map[string]any{},
+			want:      true,
+		},
+		{
+			name:      "Test $isNumber operator true",
+			condition: map[string]any{"{{price}}": map[string]any{"$isNumber": true}},
+			instance:  map[string]any{"price": 9.99},
+			want:      true,
+		},
+		{
+			name:      "Test $isString operator false",
+			condition: map[string]any{"{{price}}": map[string]any{"$isString": false}},
+			instance:  map[string]any{"price": 9.99},
+			want:      true,
+		},
+		{
+			name:      "Test $isTime operator true",
+			condition: map[string]any{"{{at}}": map[string]any{"$isTime": true}},
+			instance:  map[string]any{"at": time.Now()},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeOperatorsRunFirst(t *testing.T) {
+	cond := NewConditions()
+	condition := map[string]any{"{{age}}": map[string]any{"$gte": 18, "$lte": 99, "$type": "integer"}}
+	instance := map[string]any{"age": "42"}
+
+	// Without a fixed order, the comparisons could run before the type check and report an error.
+	for i := 0; i < 50; i++ {
+		got, err := cond.Evaluate(instance, condition)
+		if got || err != nil {
+			t.Fatalf("Evaluate() = %v, %v, want false and no error", got, err)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package conditions
 
 import (
+	"cmp"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +19,20 @@ func contains[T comparable](s []T, e string) bool {
 	return false
 }
 
+// isNil reports whether value is nil or a nil pointer, slice, map, channel, function or interface.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
 func isInCollection(collection any, element any) bool {
 	val := reflect.ValueOf(collection)
 	switch val.Kind() {
@@ -35,31 +52,148 @@ func isInCollection(collection any, element any) bool {
 }
 
 // Returns 0 if equal, -1 if v1 < v2, 1 if v1 > v2, and an error if incomparable.
+// Strings are compared lexicographically unless both are durations or both byte sizes, and RFC 3339 strings can be compared with time.Time values.
+// Duration strings like "15m" and byte sizes like "25MiB" can be compared with time.Duration values and numbers.
 func compareNumbersOrDates(v1, v2 any) (int, error) {
 	switch v1Typed := v1.(type) {
-	case float64, float32, int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8:
-		f1, _ := toFloat64(v1)
-		f2, _ := toFloat64(v2)
-
-		if f1 < f2 {
-			return -1, nil
-		} else if f1 > f2 {
-			return 1, nil
+	case time.Duration:
+		d2, ok := toDuration(v2)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare time.Duration with %T", v2)
 		}
-		return 0, nil
+		return cmp.Compare(v1Typed, d2), nil
 	case time.Time:
-		t2, ok := v2.(time.Time)
+		t2, err := toTime(v2)
+		if err != nil {
+			return 0, err
+		}
+		return v1Typed.Compare(t2), nil
+	case string:
+		s2, ok := v2.(string)
 		if !ok {
-			return 0, fmt.Errorf("cannot compare time.Time with non-time.Time")
+			return compareStringWith(v1Typed, v2)
+		}
+		// Two durations or two byte sizes are compared by value, anything else as text.
+		if d1, ok := toDuration(v1Typed); ok {
+			if d2, ok := toDuration(s2); ok {
+				return cmp.Compare(d1, d2), nil
+			}
+		}
+		if size1, ok := parseByteSize(v1Typed); ok {
+			if size2, ok := parseByteSize(s2); ok {
+				return cmp.Compare(size1, size2), nil
+			}
 		}
-		if v1Typed.Before(t2) {
-			return -1, nil
-		} else if v1Typed.After(t2) {
-			return 1, nil
+		return strings.Compare(v1Typed, s2), nil
+	default:
+		if _, ok := toNumber(v1); !ok {
+			return 0, fmt.Errorf("unsupported type for comparison: %T", v1)
+		}
+		if isNaN(v1) || isNaN(v2) {
+			return 0, fmt.Errorf("cannot order NaN")
+		}
+		if result, ok := compareNumbers(v1, v2); ok {
+			return result, nil
+		}
+		if size, ok := parseByteSizeValue(v2); ok {
+			result, _ := compareNumbers(v1, size)
+			return result, nil
+		}
+		return 0, fmt.Errorf("cannot compare %T with %T", v1, v2)
+	}
+}
+
+// compareStringWith compares a string holding a time, duration or byte size with a value of another type.
+func compareStringWith(v1 string, v2 any) (int, error) {
+	switch v2.(type) {
+	case time.Time:
+		t1, err := toTime(v1)
+		if err != nil {
+			return 0, err
+		}
+		return compareNumbersOrDates(t1, v2)
+	case time.Duration:
+		d1, ok := toDuration(v1)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare time.Duration with non-duration string %q", v1)
+		}
+		return compareNumbersOrDates(d1, v2)
+	default:
+		if size, ok := parseByteSize(v1); ok {
+			return compareNumbersOrDates(size, v2)
+		}
+		return 0, fmt.Errorf("cannot compare %T with %T", v1, v2)
+	}
+}
+
+// toDuration converts a time.Duration or a duration string like "1h30m" to time.Duration.
+func toDuration(value any) (time.Duration, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, true
+	case string:
+		d, err := time.ParseDuration(strings.TrimSpace(v))
+		return d, err == nil
+	default:
+		return 0, false
+	}
+}
+
+var byteSizeUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// parseByteSize parses sizes like "25MiB", "1.5 GB" or "512B" into a number of bytes.
+// The unit is required and case-insensitive; KB, MB, ... are decimal and KiB, MiB, ... are binary.
+func parseByteSize(value string) (float64, bool) {
+	str := strings.TrimSpace(value)
+	i := strings.IndexFunc(str, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i <= 0 {
+		return 0, false
+	}
+
+	multiplier, ok := byteSizeUnits[strings.ToLower(strings.TrimSpace(str[i:]))]
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(str[:i], 64)
+	if err != nil {
+		return 0, false
+	}
+	return number * multiplier, true
+}
+
+func parseByteSizeValue(value any) (float64, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	return parseByteSize(str)
+}
+
+// toTime converts a time.Time or an RFC 3339 string to time.Time.
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot compare time.Time with non-RFC 3339 string %q", v)
 		}
-		return 0, nil
+		return t, nil
 	default:
-		return 0, fmt.Errorf("unsupported type for comparison")
+		return time.Time{}, fmt.Errorf("cannot compare time.Time with %T", value)
 	}
 }
 
@@ -0,0 +1,53 @@
+package conditions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareNumbersOrDates(t *testing.T) {
+	moment := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		v1      any
+		v2      any
+		want    int
+		wantErr bool
+	}{
+		{name: "ints", v1: 1, v2: 2, want: -1},
+		{name: "int and float", v1: 2, v2: 1.5, want: 1},
+		{name: "strings", v1: "A100", v2: "A099", want: 1},
+		{name: "equal strings", v1: "abc", v2: "abc", want: 0},
+		{name: "times", v1: moment, v2: moment.Add(time.Hour), want: -1},
+		{name: "time and RFC 3339 string", v1: moment, v2: "2024-05-01T12:00:00Z", want: 0},
+		{name: "RFC 3339 string and time", v1: "2024-05-01T13:00:00+02:00", v2: moment, want: -1},
+		{name: "time and invalid string", v1: moment, v2: "yesterday", wantErr: true},
+		{name: "durations", v1: 20 * time.Minute, v2: 15 * time.Minute, want: 1},
+		{name: "duration and string", v1: 90 * time.Second, v2: "1m30s", want: 0},
+		{name: "string and duration", v1: "2h", v2: 150 * time.Minute, want: -1},
+		{name: "duration and invalid string", v1: time.Second, v2: "soon", wantErr: true},
+		{name: "duration strings", v1: "10m", v2: "9m", want: 1},
+		{name: "number and byte size", v1: 20 << 20, v2: "25MiB", want: -1},
+		{name: "byte size and number", v1: "1.5 GB", v2: 1500000000, want: 0},
+		{name: "byte size strings", v1: "9b", v2: "10b", want: -1},
+		{name: "binary and decimal byte size strings", v1: "1KiB", v2: "1kB", want: 1},
+		{name: "duration and byte size strings are text", v1: "9m", v2: "10b", want: 1},
+		{name: "numeric strings are text", v1: "0", v2: "10", want: -1},
+		{name: "number and string", v1: 42, v2: "42", wantErr: true},
+		{name: "string and number", v1: "42", v2: 42, wantErr: true},
+		{name: "unsupported type", v1: []int{1}, v2: []int{2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compareNumbersOrDates(tt.v1, tt.v2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compareNumbersOrDates(%v, %v) error = %v, wantErr %v", tt.v1, tt.v2, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("compareNumbersOrDates(%v, %v) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+			}
+		})
+	}
+}
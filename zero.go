@@ -0,0 +1,114 @@
+package conditions
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// maxZeroDepth bounds the recursion into nested structs and pointers, so cyclic values terminate.
+const maxZeroDepth = 32
+
+// zeroer is implemented by types like time.Time that define their own zero value.
+type zeroer interface {
+	IsZero() bool
+}
+
+// indirect follows pointers and driver.Valuer types like sql.NullString to the value they hold.
+// It returns nil for nil pointers and SQL NULLs.
+func indirect(fact any) any {
+	for depth := 0; depth < maxZeroDepth; depth++ {
+		if isNil(fact) {
+			return nil
+		}
+		if valuer, ok := fact.(driver.Valuer); ok {
+			value, err := valuer.Value()
+			if err != nil {
+				return fact
+			}
+			fact = value
+			continue
+		}
+		v := reflect.ValueOf(fact)
+		if v.Kind() != reflect.Pointer {
+			return fact
+		}
+		fact = v.Elem().Interface()
+	}
+	return fact
+}
+
+// isZero reports whether the fact is the zero value of its type. Pointers and SQL nullable types are
+// followed, structs are zero when all their fields are, and strings, lists, maps and channels when they
+// are empty. Types implementing IsZero() bool decide for themselves.
+func isZero(fact any) bool {
+	return isZeroValue(reflect.ValueOf(fact), 0)
+}
+
+func isZeroValue(v reflect.Value, depth int) bool {
+	if !v.IsValid() {
+		return true
+	}
+	if depth > maxZeroDepth {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		if v.IsNil() {
+			return true
+		}
+	}
+	if v.CanInterface() {
+		switch value := v.Interface().(type) {
+		case zeroer:
+			return value.IsZero()
+		case driver.Valuer:
+			if resolved, err := value.Value(); err == nil {
+				return isZeroValue(reflect.ValueOf(resolved), depth+1)
+			}
+		}
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return isZeroValue(v.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !isZeroValue(v.Field(i), depth+1) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !isZeroValue(v.Index(i), depth+1) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Map, reflect.Chan, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// isEmpty reports whether the fact has no content: strings, lists, maps and channels of length zero,
+// zero structs and times, and SQL NULLs. Pointers are followed; a nil pointer is not empty but null.
+func isEmpty(fact any) bool {
+	v := reflect.ValueOf(fact)
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return false
+	}
+	fact = indirect(fact)
+	if fact == nil {
+		return true
+	}
+	v = reflect.ValueOf(fact)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice, reflect.String, reflect.Map, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Struct:
+		return isZeroValue(v, 0)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,151 @@
+package conditions
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+type address struct {
+	Street string
+	Zip    *int
+	tags   []string
+}
+
+type revision struct {
+	id int
+}
+
+func (r revision) IsZero() bool {
+	return r.id <= 1
+}
+
+func TestZeroValues(t *testing.T) {
+	cond := NewConditions()
+	zip := 10115
+	name := ""
+
+	tests := []struct {
+		name      string
+		condition map[string]any
+		instance  any
+		want      bool
+	}{
+		{
+			name:      "Test $zero with zero struct",
+			condition: map[string]any{"$zero": "{{address}}"},
+			instance:  map[string]any{"address": address{}},
+			want:      true,
+		},
+		{
+			name:      "Test $zero with struct holding a pointer",
+			condition: map[string]any{"$zero": "{{address}}"},
+			instance:  map[string]any{"address": address{Zip: &zip}},
+			want:      false,
+		},
+		{
+			name:      "Test $zero with unexported field",
+			condition: map[string]any{"$zero": "{{address}}"},
+			instance:  map[string]any{"address": address{tags: []string{"home"}}},
+			want:      false,
+		},
+		{
+			name:      "Test $zero with IsZero method",
+			condition: map[string]any{"$zero": "{{revision}}"},
+			instance:  map[string]any{"revision": revision{id: 1}},
+			want:      true,
+		},
+		{
+			name:      "Test $zero with zero time",
+			condition: map[string]any{"$zero": "{{deletedAt}}"},
+			instance:  map[string]any{"deletedAt": time.Time{}},
+			want:      true,
+		},
+		{
+			name:      "Test $zero with pointer to empty string",
+			condition: map[string]any{"$zero": "{{name}}"},
+			instance:  map[string]any{"name": &name},
+			want:      true,
+		},
+		{
+			name:      "Test $zero with valid sql.NullInt64",
+			condition: map[string]any{"$zero": "{{count}}"},
+			instance:  map[string]any{"count": sql.NullInt64{Int64: 3, Valid: true}},
+			want:      false,
+		},
+		{
+			name:      "Test $zero with number",
+			condition: map[string]any{"$zero": "{{count}}"},
+			instance:  map[string]any{"count": 0.0},
+			want:      true,
+		},
+		{
+			name:      "Test $empty with zero struct",
+			condition: map[string]any{"$empty": "{{address}}"},
+			instance:  map[string]any{"address": address{}},
+			want:      true,
+		},
+		{
+			name:      "Test $empty with pointer to struct",
+			condition: map[string]any{"$empty": "{{address}}"},
+			instance:  map[string]any{"address": &address{Street: "Main St"}},
+			want:      false,
+		},
+		{
+			name:      "Test $empty with nil pointer",
+			condition: map[string]any{"$empty": "{{address}}"},
+			instance:  map[string]any{"address": (*address)(nil)},
+			want:      false,
+		},
+		{
+			name:      "Test $empty with missing field",
+			condition: map[string]any{"$empty": "{{address}}"},
+			instance:  map[string]any{},
+			want:      false,
+		},
+		{
+			name:      "Test $empty with zero time",
+			condition: map[string]any{"$empty": "{{deletedAt}}"},
+			instance:  map[string]any{"deletedAt": time.Time{}},
+			want:      true,
+		},
+		{
+			name:      "Test $empty with null sql.NullString",
+			condition: map[string]any{"$empty": "{{nickname}}"},
+			instance:  map[string]any{"nickname": sql.NullString{}},
+			want:      true,
+		},
+		{
+			name:      "Test $empty with buffered channel",
+			condition: map[string]any{"$empty": "{{queue}}"},
+			instance:  map[string]any{"queue": make(chan int, 1)},
+			want:      true,
+		},
+		{
+			name:      "Test $blank with whitespace sql.NullString",
+			condition: map[string]any{"$blank": "{{nickname}}"},
+			instance:  map[string]any{"nickname": sql.NullString{String: "  ", Valid: true}},
+			want:      true,
+		},
+		{
+			name:      "Test $blank with nil pointer",
+			condition: map[string]any{"$blank": "{{address}}"},
+			instance:  map[string]any{"address": (*address)(nil)},
+			want:      true,
+		},
+		{
+			name:      "Test $blank with non-zero time",
+			condition: map[string]any{"$blank": "{{createdAt}}"},
+			instance:  map[string]any{"createdAt": time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cond.Check(tt.instance, tt.condition); got != tt.want {
+				t.Errorf("Check() for %s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}